@@ -0,0 +1,343 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCheckpointInterval is the number of records written by an [AuditLog]
+// between checkpoints, unless a different interval is set with [WithCheckpointInterval].
+const DefaultCheckpointInterval = 1000
+
+// maxAuditFrameSize is the maximum size of a single record or checkpoint
+// accepted by [VerifyAuditLog].
+const maxAuditFrameSize = 16 << 20
+
+// AuditLogOption configures [NewAuditLog]. Every [Option] is also an AuditLogOption.
+type AuditLogOption interface {
+	applyAuditLog(*auditLogOptions)
+}
+
+type auditLogOptions struct {
+	options
+	interval int
+	signers  []*NoteSigner
+}
+
+type checkpointIntervalOption int
+
+func (n checkpointIntervalOption) applyAuditLog(o *auditLogOptions) {
+	o.interval = int(n)
+}
+
+// WithCheckpointInterval sets the number of records written by an [AuditLog]
+// between checkpoints. A negative interval disables automatic checkpoints.
+func WithCheckpointInterval(n int) AuditLogOption {
+	return checkpointIntervalOption(n)
+}
+
+type checkpointSignersOption []*NoteSigner
+
+func (signers checkpointSignersOption) applyAuditLog(o *auditLogOptions) {
+	o.signers = signers
+}
+
+// WithCheckpointSigners sets the signers of the checkpoints written by an [AuditLog].
+func WithCheckpointSigners(signers ...*NoteSigner) AuditLogOption {
+	return checkpointSignersOption(signers)
+}
+
+var (
+	// ErrMalformedAuditLog is returned by [VerifyAuditLog] if
+	// the log is not in the format written by an [AuditLog].
+	ErrMalformedAuditLog = errors.New("malformed audit log")
+
+	// ErrTruncatedAuditLog is returned by [VerifyAuditLog] if the log ends
+	// in the middle of a record or with records which are not covered by a
+	// checkpoint, e.g. because its final checkpoint was removed.
+	ErrTruncatedAuditLog = errors.New("truncated audit log")
+)
+
+// AuditLog is a tamper-evident log where every record is appended as a leaf of a
+// merkle tree. Every so often a checkpoint, containing the size and root hash of
+// the tree, is written after the records, so every checkpoint commits to every
+// record written before it. Modifying, removing or reordering any record is then
+// detected by [VerifyAuditLog] when replaying the log.
+//
+// The log is written in frames, which are a header line of the form "record <n>"
+// or "checkpoint <n>", followed by n bytes of content and a newline. The content
+// of a checkpoint is a [TreeHead], which is signed if any signers are set with
+// [WithCheckpointSigners]. Without signatures, an attacker can rewrite the log
+// and recompute every checkpoint, so the checkpoints should either be signed or
+// also published somewhere else.
+//
+// An AuditLog is safe for concurrent use.
+type AuditLog struct {
+	w        io.Writer
+	hasher   hash.Hash
+	origin   string
+	scheme   Scheme
+	interval int
+	signers  []*NoteSigner
+
+	mu        sync.Mutex
+	edge      frontier
+	unchecked int
+}
+
+// NewAuditLog returns an [AuditLog] which writes to the given writer. The
+// origin identifies the log in its checkpoints, e.g. "example.com/audit".
+func NewAuditLog(w io.Writer, hasher hash.Hash, origin string, opts ...AuditLogOption) *AuditLog {
+	var o auditLogOptions
+	for _, opt := range opts {
+		opt.applyAuditLog(&o)
+	}
+	if o.interval == 0 {
+		o.interval = DefaultCheckpointInterval
+	}
+
+	return &AuditLog{
+		w:        w,
+		hasher:   hasher,
+		origin:   origin,
+		scheme:   o.scheme,
+		interval: o.interval,
+		signers:  o.signers,
+	}
+}
+
+// Write implements the [io.Writer] interface. Every call appends p as a single
+// record, like every call to a [log.Logger] writes a single line, and writes a
+// checkpoint if the checkpoint interval has been reached.
+func (l *AuditLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hash, err := l.scheme.hashLeaf(l.hasher, bytes.NewReader(p))
+	if err != nil {
+		return 0, err
+	}
+
+	edge, _, err := l.edge.append(l.hasher, l.scheme, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writeAuditFrame(l.w, "record", p)
+	if err != nil {
+		return 0, err
+	}
+	l.edge = edge
+	l.unchecked++
+
+	if l.interval > 0 && l.unchecked >= l.interval {
+		// the record has already been written, so it must not be
+		// reported as unwritten and retried by the caller
+		err = l.checkpoint()
+		if err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Checkpoint writes a checkpoint for every record written so far, unless the
+// latest checkpoint already covers them. It should be called before the log is
+// closed, since [VerifyAuditLog] rejects records not covered by a checkpoint.
+func (l *AuditLog) Checkpoint() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.unchecked == 0 {
+		return nil
+	}
+	return l.checkpoint()
+}
+
+func (l *AuditLog) checkpoint() error {
+	root, err := l.edge.root(l.hasher, l.scheme)
+	if err != nil {
+		return err
+	}
+
+	head := &TreeHead{
+		Origin:    l.origin,
+		Size:      l.edge.size,
+		Root:      root,
+		Timestamp: time.Now(),
+	}
+
+	var content []byte
+	if len(l.signers) > 0 {
+		content, err = SignTreeHead(head, l.signers...)
+	} else {
+		content, err = head.MarshalText()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = writeAuditFrame(l.w, "checkpoint", content)
+	if err != nil {
+		return err
+	}
+	l.unchecked = 0
+	return nil
+}
+
+// writeAuditFrame writes the frame with a single call to Write,
+// so frames are never interleaved in the underlying writer.
+func writeAuditFrame(w io.Writer, kind string, content []byte) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %d\n", kind, len(content))
+	buf.Write(content)
+	buf.WriteByte('\n')
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// VerifyAuditLog replays the audit log read from the given reader and verifies
+// that every checkpoint matches the records written before it. The hasher and
+// scheme must be the same as those used by the [AuditLog] which wrote it. Every
+// checkpoint must be signed by all of the given verifiers. If none are given, the
+// signatures of checkpoints are not checked, whether or not they are signed.
+//
+// It returns the final checkpoint of the log, or nil if the log is empty.
+// Removing records from the end of the log, along with their checkpoints,
+// can only be detected by comparing the returned checkpoint with one that
+// was published elsewhere.
+func VerifyAuditLog(hasher hash.Hash, r io.Reader, verifiers []*NoteVerifier, opts ...Option) (*TreeHead, error) {
+	o := applyOptions(opts)
+
+	var edge frontier
+	var head *TreeHead
+	unchecked := 0
+
+	br := bufio.NewReader(r)
+	for {
+		kind, content, err := readAuditFrame(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case "record":
+			hash, err := o.scheme.hashLeaf(hasher, bytes.NewReader(content))
+			if err != nil {
+				return nil, err
+			}
+			edge, _, err = edge.append(hasher, o.scheme, hash)
+			if err != nil {
+				return nil, err
+			}
+			unchecked++
+		case "checkpoint":
+			checkpoint, err := openAuditCheckpoint(content, verifiers)
+			if err != nil {
+				return nil, fmt.Errorf("checkpoint after record %d: %w", edge.size, err)
+			}
+			if head != nil && checkpoint.Origin != head.Origin {
+				return nil, fmt.Errorf("checkpoint after record %d: %w", edge.size, ErrMalformedAuditLog)
+			}
+			if checkpoint.Size != edge.size {
+				return nil, fmt.Errorf("checkpoint after record %d: %w", edge.size, ErrInvalidTreeSize)
+			}
+
+			root, err := edge.root(hasher, o.scheme)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(root, checkpoint.Root) {
+				return nil, fmt.Errorf("checkpoint after record %d: %w", edge.size, ErrRootMismatch)
+			}
+
+			head = checkpoint
+			unchecked = 0
+		default:
+			return nil, ErrMalformedAuditLog
+		}
+	}
+
+	if unchecked > 0 {
+		return nil, ErrTruncatedAuditLog
+	}
+	return head, nil
+}
+
+// readAuditFrame reads the next frame, returning [io.EOF] only if
+// the reader ends exactly at the start of a frame.
+func readAuditFrame(r *bufio.Reader) (string, []byte, error) {
+	header, err := r.ReadString('\n')
+	if err == io.EOF && header == "" {
+		return "", nil, io.EOF
+	}
+	if err == io.EOF {
+		return "", nil, ErrTruncatedAuditLog
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	kind, length, ok := strings.Cut(strings.TrimSuffix(header, "\n"), " ")
+	if !ok {
+		return "", nil, ErrMalformedAuditLog
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil || n < 0 || n > maxAuditFrameSize {
+		return "", nil, ErrMalformedAuditLog
+	}
+
+	content := make([]byte, n+1)
+	_, err = io.ReadFull(r, content)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return "", nil, ErrTruncatedAuditLog
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if content[n] != '\n' {
+		return "", nil, ErrMalformedAuditLog
+	}
+	return kind, content[:n], nil
+}
+
+func openAuditCheckpoint(content []byte, verifiers []*NoteVerifier) (*TreeHead, error) {
+	if len(verifiers) > 0 {
+		return OpenTreeHead(content, len(verifiers), verifiers...)
+	}
+
+	// without verifiers the signatures of a signed checkpoint
+	// can not be checked, so only its tree head is parsed
+	text := content
+	if bytes.Contains(content, []byte("\n\n")) {
+		var err error
+		text, _, err = splitNote(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var head TreeHead
+	err := head.UnmarshalText(text)
+	if err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
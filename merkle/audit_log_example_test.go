@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"log"
+)
+
+func ExampleAuditLog() {
+	var buf bytes.Buffer
+	audit := NewAuditLog(&buf, sha256.New(), "example.com/audit", WithCheckpointInterval(2))
+
+	logger := log.New(audit, "", 0)
+	logger.Println("user alice logged in")
+	logger.Println("user alice changed their password")
+	logger.Println("user alice logged out")
+
+	err := audit.Checkpoint()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	head, err := VerifyAuditLog(sha256.New(), &buf, nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(head.Origin, head.Size)
+	// Output: example.com/audit 3
+}
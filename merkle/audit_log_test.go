@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errWriteFailed = errors.New("failed to write")
+
+type writeFunc func([]byte) (int, error)
+
+func (f writeFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
+
+// writeAuditLog writes n records to a new audit log and returns its content.
+func writeAuditLog(t *testing.T, n int, opts ...AuditLogOption) []byte {
+	var buf bytes.Buffer
+	l := NewAuditLog(&buf, sha256.New(), "example.com/audit", opts...)
+
+	for i := range n {
+		_, err := l.Write([]byte(strconv.Itoa(i)))
+		require.Nil(t, err)
+	}
+	require.Nil(t, l.Checkpoint())
+	return buf.Bytes()
+}
+
+func TestAuditLog(t *testing.T) {
+	t.Run("will checkpoint the root of a BinaryTree of its records", func(t *testing.T) {
+		for _, n := range []int{1, 2, 3, 7, 8, 25} {
+			b := writeAuditLog(t, n, WithCheckpointInterval(4))
+
+			head, err := VerifyAuditLog(sha256.New(), bytes.NewReader(b), nil)
+			require.Nil(t, err)
+			require.Equal(t, "example.com/audit", head.Origin)
+			require.Equal(t, uint64(n), head.Size)
+
+			tree, err := NewBinaryTree(sha256.New(), leafValues(n))
+			require.Nil(t, err)
+			require.Equal(t, tree.Hash(), head.Root)
+
+			checkpoints := bytes.Count(b, []byte("checkpoint "))
+			require.Equal(t, (n+3)/4, checkpoints)
+		}
+	})
+
+	t.Run("will only checkpoint when asked to if automatic checkpoints are disabled", func(t *testing.T) {
+		b := writeAuditLog(t, 10, WithCheckpointInterval(-1))
+		require.Equal(t, 1, bytes.Count(b, []byte("checkpoint ")))
+	})
+
+	t.Run("will write a record per call from a log.Logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := NewAuditLog(&buf, sha256.New(), "example.com/audit")
+
+		logger := log.New(l, "", 0)
+		logger.Println("user alice logged in")
+		logger.Println("user alice\nlogged out")
+		require.Nil(t, l.Checkpoint())
+		require.Nil(t, l.Checkpoint())
+
+		head, err := VerifyAuditLog(sha256.New(), &buf, nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(2), head.Size)
+	})
+
+	t.Run("will verify signed checkpoints", func(t *testing.T) {
+		signer := newTestSigner(t, "example.com/audit", 1)
+		witness := newTestSigner(t, "example.com/witness", 2)
+		b := writeAuditLog(t, 10, WithCheckpointInterval(3), WithCheckpointSigners(signer, witness))
+
+		head, err := VerifyAuditLog(sha256.New(), bytes.NewReader(b), []*NoteVerifier{signer.Verifier(), witness.Verifier()})
+		require.Nil(t, err)
+		require.Equal(t, uint64(10), head.Size)
+	})
+
+	t.Run("will ignore the signatures of checkpoints without verifiers", func(t *testing.T) {
+		signer := newTestSigner(t, "example.com/audit", 1)
+		b := writeAuditLog(t, 1, WithCheckpointSigners(signer))
+
+		head, err := VerifyAuditLog(sha256.New(), bytes.NewReader(b), nil)
+		require.Nil(t, err)
+		require.Equal(t, uint64(1), head.Size)
+	})
+
+	t.Run("will return nil for an empty log", func(t *testing.T) {
+		head, err := VerifyAuditLog(sha256.New(), bytes.NewReader(writeAuditLog(t, 0)), nil)
+		require.Nil(t, err)
+		require.Nil(t, head)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		b := writeAuditLog(t, 6, WithCheckpointInterval(3))
+
+		t.Run("if a record was modified", func(t *testing.T) {
+			modified := bytes.Replace(b, []byte("record 1\n1\n"), []byte("record 1\n9\n"), 1)
+
+			_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(modified), nil)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if records were reordered", func(t *testing.T) {
+			reordered := bytes.Replace(b, []byte("record 1\n1\nrecord 1\n2\n"), []byte("record 1\n2\nrecord 1\n1\n"), 1)
+			require.NotEqual(t, b, reordered)
+
+			_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(reordered), nil)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if a record was removed", func(t *testing.T) {
+			removed := bytes.Replace(b, []byte("record 1\n4\n"), nil, 1)
+
+			_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(removed), nil)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+		})
+
+		t.Run("if the final checkpoint was removed", func(t *testing.T) {
+			i := bytes.LastIndex(b, []byte("checkpoint "))
+
+			_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(b[:i]), nil)
+			require.ErrorIs(t, err, ErrTruncatedAuditLog)
+		})
+
+		t.Run("if the log ends in the middle of a frame", func(t *testing.T) {
+			for _, n := range []int{1, 5, len(b) - 1} {
+				_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(b[:n]), nil)
+				require.ErrorIs(t, err, ErrTruncatedAuditLog)
+			}
+		})
+
+		t.Run("if a frame is malformed", func(t *testing.T) {
+			testCases := []string{
+				"record\n",
+				"record x\n",
+				"record -1\n",
+				"record 1\n12",
+				"entry 1\n1\n",
+			}
+
+			for _, testCase := range testCases {
+				_, err := VerifyAuditLog(sha256.New(), strings.NewReader(testCase+"\n"), nil)
+				require.ErrorIs(t, err, ErrMalformedAuditLog, fmt.Sprintf("%q", testCase))
+			}
+		})
+
+		t.Run("if a checkpoint is not signed by every verifier", func(t *testing.T) {
+			signer := newTestSigner(t, "example.com/audit", 1)
+			witness := newTestSigner(t, "example.com/witness", 2)
+			signed := writeAuditLog(t, 3, WithCheckpointSigners(signer))
+
+			_, err := VerifyAuditLog(sha256.New(), bytes.NewReader(signed), []*NoteVerifier{signer.Verifier(), witness.Verifier()})
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+
+			_, err = VerifyAuditLog(sha256.New(), bytes.NewReader(b), []*NoteVerifier{signer.Verifier()})
+			require.ErrorIs(t, err, ErrMalformedNote)
+		})
+
+		t.Run("if the writer fails", func(t *testing.T) {
+			l := NewAuditLog(writeFunc(func(b []byte) (int, error) {
+				return 0, errWriteFailed
+			}), sha256.New(), "example.com/audit")
+
+			_, err := l.Write([]byte("a"))
+			require.ErrorIs(t, err, errWriteFailed)
+		})
+
+		t.Run("if the automatic checkpoint fails after the record was written", func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewAuditLog(writeFunc(func(b []byte) (int, error) {
+				if bytes.HasPrefix(b, []byte("checkpoint ")) {
+					return 0, errWriteFailed
+				}
+				return buf.Write(b)
+			}), sha256.New(), "example.com/audit", WithCheckpointInterval(1))
+
+			n, err := l.Write([]byte("a"))
+			require.ErrorIs(t, err, errWriteFailed)
+			require.Equal(t, 1, n)
+			require.Equal(t, "record 1\na\n", buf.String())
+		})
+	})
+}
@@ -6,7 +6,7 @@
 package merkle
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"hash"
@@ -18,6 +18,12 @@ type BinaryTree struct {
 	hash  []byte
 	left  *BinaryTree
 	right *BinaryTree
+
+	// size is the number of leaves contained in this tree.
+	size int
+
+	// scheme is the [Scheme] used to compute the hash of this tree.
+	scheme Scheme
 }
 
 // ErrAtLeastOneLeafRequired is returned if a [BinaryTree] is zero leaf nodes are
@@ -26,26 +32,66 @@ var ErrAtLeastOneLeafRequired = errors.New("must provide at least one leaf")
 
 // ConstructBinaryTree will construct a full merkle [BinaryTree] from the given leaf nodes.
 func ConstructBinaryTree[T io.Reader](hasher hash.Hash, leafs ...T) (*BinaryTree, error) {
+	return NewBinaryTree(hasher, leafs)
+}
+
+// BinaryTreeOption configures [NewBinaryTree] and [NewBinaryTreeContext]. Every
+// [Option] is also a BinaryTreeOption, and every BinaryTreeOption is also a
+// [ParallelOption].
+type BinaryTreeOption interface {
+	ParallelOption
+	applyBinaryTree(*binaryTreeOptions)
+}
+
+type binaryTreeOptions struct {
+	options
+	progress func(Progress)
+}
+
+// NewBinaryTree will construct a full merkle [BinaryTree] from the given leaf nodes.
+// Unlike [ConstructBinaryTree], the construction can be customized by the given options.
+func NewBinaryTree[T io.Reader](hasher hash.Hash, leafs []T, opts ...BinaryTreeOption) (*BinaryTree, error) {
+	return NewBinaryTreeContext(context.Background(), hasher, leafs, opts...)
+}
+
+// NewBinaryTreeContext will construct a full merkle [BinaryTree] from the given leaf nodes.
+// The construction is aborted as soon as the given context is cancelled, even while in
+// the middle of reading a leaf.
+func NewBinaryTreeContext[T io.Reader](ctx context.Context, hasher hash.Hash, leafs []T, opts ...BinaryTreeOption) (*BinaryTree, error) {
 	if len(leafs) == 0 {
 		return nil, ErrAtLeastOneLeafRequired
 	}
 
+	var o binaryTreeOptions
+	for _, opt := range opts {
+		opt.applyBinaryTree(&o)
+	}
+	tracker := newProgressTracker(o.progress, len(leafs))
+
 	nodes := make([]*BinaryTree, len(leafs))
 	for i, leaf := range leafs {
-		hash, err := hashAll(hasher, leaf)
+		hash, err := o.scheme.hashLeaf(hasher, leafReader{ctx: ctx, r: leaf, tracker: tracker})
 		if err != nil {
 			return nil, err
 		}
+		tracker.leafHashed()
 
 		nodes[i] = &BinaryTree{
-			hash: hash,
+			hash:   hash,
+			size:   1,
+			scheme: o.scheme,
 		}
 	}
 
-	return constructBinaryTree(hasher, nodes)
+	return constructBinaryTree(ctx, tracker, o.scheme, hasher, nodes, 1)
 }
 
-func constructBinaryTree(hasher hash.Hash, nodes []*BinaryTree) (*BinaryTree, error) {
+func constructBinaryTree(ctx context.Context, tracker *progressTracker, scheme Scheme, hasher hash.Hash, nodes []*BinaryTree, level int) (*BinaryTree, error) {
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	tracker.level(level)
+
 	numOfNewNodes := len(nodes) / 2
 
 	hasOddNumOfNodes := len(nodes)%2 != 0
@@ -59,45 +105,29 @@ func constructBinaryTree(hasher hash.Hash, nodes []*BinaryTree) (*BinaryTree, er
 		nodes = nodes[:len(nodes)-1]
 	}
 
-	var buf bytes.Buffer
 	for i := 0; i < len(nodes); i += 2 {
-		buf.Reset()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
 		left := nodes[i]
-		buf.Write(left.hash)
-
 		right := nodes[i+1]
-		buf.Write(right.hash)
 
-		hash, err := hashAll(hasher, &buf)
+		hash, err := scheme.hashChildren(hasher, left.hash, right.hash)
 		if err != nil {
 			return nil, err
 		}
 
 		newNodes[i/2] = &BinaryTree{
-			hash:  hash,
-			left:  left,
-			right: right,
+			hash:   hash,
+			left:   left,
+			right:  right,
+			size:   left.size + right.size,
+			scheme: scheme,
 		}
 	}
-	if len(newNodes) == 1 {
-		return newNodes[0], nil
-	}
-
-	return constructBinaryTree(hasher, newNodes)
-}
-
-func hashAll(hasher hash.Hash, r io.Reader) ([]byte, error) {
-	// ensure state independent hashes aka each node hash is reproducible
-	// and independent of the hashing operations that came before it
-	hasher.Reset()
-
-	_, err := io.Copy(hasher, r)
-	if err != nil {
-		return nil, err
-	}
 
-	return hasher.Sum(nil), nil
+	return constructBinaryTree(ctx, tracker, scheme, hasher, newNodes, level+1)
 }
 
 // Hash returns the raw hash value for this tree.
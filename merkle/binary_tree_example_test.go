@@ -8,6 +8,7 @@ package merkle
 import (
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -29,3 +30,19 @@ func ExampleBinaryTree() {
 	// Output: e5a01fee14e0ed5c48714f22180f25ad8365b53f9779f79dc4a3d7e93963f94a
 	// e5a01fee14e0ed5c48714f22180f25ad8365b53f9779f79dc4a3d7e93963f94a
 }
+
+func ExampleNewBinaryTree() {
+	leafs := []io.Reader{
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+	}
+
+	tree, err := NewBinaryTree(sha256.New(), leafs, WithScheme(DomainSeparatedScheme))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(tree)
+
+	// Output: b137985ff484fb600db93107c77b0365c80d78f5b429ded0fd97361d077999eb
+}
@@ -54,22 +54,12 @@ func FuzzConstructBinaryTree(f *testing.F) {
 		require.Nil(t, err)
 
 		var foundLeaves uint
-		walk(tree, func(bt *BinaryTree) {
-			if bt.IsLeaf() {
-				foundLeaves += 1
-			}
-		})
+		for _, leaf := range tree.Leaves() {
+			require.True(t, leaf.IsLeaf())
+			foundLeaves += 1
+		}
 
 		require.Equal(t, numOfLeafs, foundLeaves)
+		require.Equal(t, int(numOfLeafs), tree.Size())
 	})
 }
-
-func walk(tree *BinaryTree, f func(*BinaryTree)) {
-	if tree.IsLeaf() {
-		f(tree)
-		return
-	}
-
-	walk(tree.Left(), f)
-	walk(tree.Right(), f)
-}
@@ -0,0 +1,325 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"hash"
+	"io"
+	"math/bits"
+	"slices"
+)
+
+// BinaryTreeBuilder incrementally builds an append-only merkle [BinaryTree].
+//
+// Appending a leaf and computing the root hash both take logarithmic time. In order
+// to prove inclusion and consistency for any previous size, the hash of every leaf
+// and every complete subtree is kept in memory, so memory usage grows linearly
+// with the number of leaves. Use a [StoredTree] to keep those hashes in a
+// [NodeStore] instead. A BinaryTreeBuilder is not safe for concurrent use.
+type BinaryTreeBuilder struct {
+	hasher   hash.Hash
+	scheme   Scheme
+	size     int
+	frontier frontier
+
+	// levels[l][i] is the hash of the i-th complete subtree containing 2^l leaves
+	levels [][][]byte
+}
+
+// NewBinaryTreeBuilder returns an empty [BinaryTreeBuilder] which uses the given
+// hasher to compute all leaf and interior node hashes.
+func NewBinaryTreeBuilder(hasher hash.Hash, opts ...Option) *BinaryTreeBuilder {
+	o := applyOptions(opts)
+
+	return &BinaryTreeBuilder{
+		hasher: hasher,
+		scheme: o.scheme,
+	}
+}
+
+// Size returns the number of leaves which have been appended.
+func (b *BinaryTreeBuilder) Size() int {
+	return b.size
+}
+
+// Append hashes the given leaf and appends it to the tree.
+func (b *BinaryTreeBuilder) Append(leaf io.Reader) error {
+	hash, err := b.scheme.hashLeaf(b.hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	frontier, completed, err := b.frontier.append(b.hasher, b.scheme, hash)
+	if err != nil {
+		return err
+	}
+
+	for level, hash := range completed {
+		if level == len(b.levels) {
+			b.levels = append(b.levels, nil)
+		}
+		b.levels[level] = append(b.levels[level], hash)
+	}
+
+	b.frontier = frontier
+	b.size += 1
+	return nil
+}
+
+// Root returns the root hash of the tree containing every appended leaf.
+func (b *BinaryTreeBuilder) Root() ([]byte, error) {
+	return b.frontier.root(b.hasher, b.scheme)
+}
+
+// RootAt returns the root hash the tree had when it contained the given number of leaves.
+func (b *BinaryTreeBuilder) RootAt(size int) ([]byte, error) {
+	if size == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+	if size < 0 || size > b.size {
+		return nil, ErrInvalidTreeSize
+	}
+	return b.rangeHash(0, size)
+}
+
+// Prove returns an [InclusionProof] for the leaf at the given index in
+// the tree as it was when it contained treeSize leaves.
+func (b *BinaryTreeBuilder) Prove(leafIndex, treeSize int) (*InclusionProof, error) {
+	if treeSize <= 0 || treeSize > b.size {
+		return nil, ErrInvalidTreeSize
+	}
+	if leafIndex < 0 || leafIndex >= treeSize {
+		return nil, ErrLeafIndexOutOfRange
+	}
+	return proveInclusion(b.rangeHash, leafIndex, treeSize)
+}
+
+// ProveConsistency returns a consistency proof between the tree as it was when
+// it contained oldSize leaves and the tree as it was when it contained newSize leaves.
+func (b *BinaryTreeBuilder) ProveConsistency(oldSize, newSize int) ([][]byte, error) {
+	if newSize < 0 || newSize > b.size || oldSize < 0 || oldSize > newSize {
+		return nil, ErrInvalidTreeSize
+	}
+	return proveConsistency(b.rangeHash, oldSize, newSize)
+}
+
+// BinaryTree materializes the tree containing every appended leaf.
+func (b *BinaryTreeBuilder) BinaryTree() (*BinaryTree, error) {
+	if b.size == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+	return b.materialize(0, b.size)
+}
+
+func (b *BinaryTreeBuilder) materialize(start, n int) (*BinaryTree, error) {
+	if n == 1 {
+		return &BinaryTree{
+			hash:   b.levels[0][start],
+			size:   1,
+			scheme: b.scheme,
+		}, nil
+	}
+
+	k := splitPoint(n)
+	left, err := b.materialize(start, k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.materialize(start+k, n-k)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, ok := b.completeHash(start, n)
+	if !ok {
+		hash, err = b.scheme.hashChildren(b.hasher, left.hash, right.hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BinaryTree{
+		hash:   hash,
+		left:   left,
+		right:  right,
+		size:   n,
+		scheme: b.scheme,
+	}, nil
+}
+
+// completeHash returns the stored hash of the leaves [start, start+n) and true,
+// if they form a complete subtree, otherwise it returns false.
+func (b *BinaryTreeBuilder) completeHash(start, n int) ([]byte, bool) {
+	if n&(n-1) != 0 || start%n != 0 {
+		return nil, false
+	}
+	return b.levels[bits.TrailingZeros(uint(n))][start/n], true
+}
+
+// rangeHash returns the hash of the subtree over the leaves [start, start+n).
+func (b *BinaryTreeBuilder) rangeHash(start, n int) ([]byte, error) {
+	if hash, ok := b.completeHash(start, n); ok {
+		return hash, nil
+	}
+
+	k := splitPoint(n)
+	left, err := b.rangeHash(start, k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.rangeHash(start+k, n-k)
+	if err != nil {
+		return nil, err
+	}
+	return b.scheme.hashChildren(b.hasher, left, right)
+}
+
+// frontier is the right edge of an append-only tree, also known as a compact range.
+// It holds the hash of one complete subtree per bit set in the size of the tree,
+// ordered from the largest subtree to the smallest, which is all that is needed
+// to append leaves and compute the root hash in logarithmic space.
+type frontier struct {
+	hashes [][]byte
+	size   uint64
+}
+
+// append returns the frontier after appending the leaf with the given hash, along
+// with the hash of every complete subtree completed by the leaf, ordered from the
+// leaf itself up to the largest subtree. This is exactly the post-order of the new
+// nodes. The frontier itself is left untouched, so it can be discarded on error.
+func (f frontier) append(hasher hash.Hash, scheme Scheme, hash []byte) (frontier, [][]byte, error) {
+	completed := [][]byte{hash}
+
+	// every trailing bit set in the old size is a complete subtree
+	// of the same size as the new one, so they are merged
+	merged := 0
+	for n := f.size; n&1 == 1; n >>= 1 {
+		merged++
+
+		var err error
+		hash, err = scheme.hashChildren(hasher, f.hashes[len(f.hashes)-merged], hash)
+		if err != nil {
+			return f, nil, err
+		}
+		completed = append(completed, hash)
+	}
+
+	hashes := slices.Clone(f.hashes[:len(f.hashes)-merged])
+	return frontier{
+		hashes: append(hashes, hash),
+		size:   f.size + 1,
+	}, completed, nil
+}
+
+// root folds the hashes of the frontier from right to left into the root hash.
+func (f frontier) root(hasher hash.Hash, scheme Scheme) ([]byte, error) {
+	if len(f.hashes) == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	root := f.hashes[len(f.hashes)-1]
+	for i := len(f.hashes) - 2; i >= 0; i-- {
+		var err error
+		root, err = scheme.hashChildren(hasher, f.hashes[i], root)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// rangeHashFunc returns the hash of the subtree over the leaves [start, start+n).
+// It is only ever called with ranges which correspond to a node in a tree.
+type rangeHashFunc func(start, n int) ([]byte, error)
+
+func proveInclusion(rangeHash rangeHashFunc, leafIndex, treeSize int) (*InclusionProof, error) {
+	proof := &InclusionProof{
+		LeafIndex: leafIndex,
+		TreeSize:  treeSize,
+		Path:      make([]ProofNode, 0, bits.Len(uint(treeSize))),
+	}
+
+	start, n := 0, treeSize
+	for n > 1 {
+		k := splitPoint(n)
+		if leafIndex < start+k {
+			hash, err := rangeHash(start+k, n-k)
+			if err != nil {
+				return nil, err
+			}
+
+			proof.Path = append(proof.Path, ProofNode{
+				Hash: hash,
+				Side: RightSide,
+			})
+			n = k
+			continue
+		}
+
+		hash, err := rangeHash(start, k)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.Path = append(proof.Path, ProofNode{
+			Hash: hash,
+			Side: LeftSide,
+		})
+		start += k
+		n -= k
+	}
+
+	for i, j := 0, len(proof.Path)-1; i < j; i, j = i+1, j-1 {
+		proof.Path[i], proof.Path[j] = proof.Path[j], proof.Path[i]
+	}
+	return proof, nil
+}
+
+func proveConsistency(rangeHash rangeHashFunc, oldSize, newSize int) ([][]byte, error) {
+	if oldSize == 0 || oldSize == newSize {
+		return [][]byte{}, nil
+	}
+	return rangeSubproof(rangeHash, 0, newSize, oldSize, true)
+}
+
+func rangeSubproof(rangeHash rangeHashFunc, start, n, m int, complete bool) ([][]byte, error) {
+	if m == n {
+		if complete {
+			return nil, nil
+		}
+
+		hash, err := rangeHash(start, n)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{hash}, nil
+	}
+
+	k := splitPoint(n)
+	if m <= k {
+		proof, err := rangeSubproof(rangeHash, start, k, m, complete)
+		if err != nil {
+			return nil, err
+		}
+
+		hash, err := rangeHash(start+k, n-k)
+		if err != nil {
+			return nil, err
+		}
+		return append(proof, hash), nil
+	}
+
+	proof, err := rangeSubproof(rangeHash, start+k, n-k, m-k, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := rangeHash(start, k)
+	if err != nil {
+		return nil, err
+	}
+	return append(proof, hash), nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+func ExampleBinaryTreeBuilder() {
+	b := NewBinaryTreeBuilder(sha256.New())
+	for _, record := range []string{"a", "b", "c"} {
+		err := b.Append(strings.NewReader(record))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		root, err := b.Root()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(hex.EncodeToString(root))
+	}
+
+	// Output: ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb
+	// e5a01fee14e0ed5c48714f22180f25ad8365b53f9779f79dc4a3d7e93963f94a
+	// 7075152d03a5cd92104887b476862778ec0c87be5c2fa1c0a90f87c49fad6eff
+}
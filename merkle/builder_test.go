@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFilledBuilder returns a [BinaryTreeBuilder] with numOfLeafs leaves appended.
+func newFilledBuilder(t *testing.T, numOfLeafs int, opts ...Option) *BinaryTreeBuilder {
+	b := NewBinaryTreeBuilder(sha256.New(), opts...)
+	for _, leaf := range leafValues(numOfLeafs) {
+		err := b.Append(leaf)
+		require.Nil(t, err)
+	}
+	return b
+}
+
+func TestBinaryTreeBuilder_Append(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the leaf fails to be read", func(t *testing.T) {
+			b := NewBinaryTreeBuilder(sha256.New())
+
+			err := b.Append(readFunc(func(b []byte) (int, error) {
+				return 0, errReadFailed
+			}))
+			require.ErrorIs(t, err, errReadFailed)
+			require.Zero(t, b.Size())
+		})
+	})
+}
+
+func TestBinaryTreeBuilder_Root(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if no leaves have been appended", func(t *testing.T) {
+			b := NewBinaryTreeBuilder(sha256.New())
+
+			root, err := b.Root()
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+			require.Nil(t, root)
+		})
+
+		t.Run("if the size is larger than the tree", func(t *testing.T) {
+			b := newFilledBuilder(t, 3)
+
+			root, err := b.RootAt(4)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, root)
+		})
+	})
+
+	t.Run("will match the root of a constructed tree", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			b := NewBinaryTreeBuilder(sha256.New(), WithScheme(scheme))
+			for i := range 40 {
+				err := b.Append(strings.NewReader(strconv.Itoa(i)))
+				require.Nil(t, err)
+				require.Equal(t, i+1, b.Size())
+
+				tree, err := NewBinaryTree(sha256.New(), leafValues(i+1), WithScheme(scheme))
+				require.Nil(t, err)
+
+				root, err := b.Root()
+				require.Nil(t, err)
+				require.Equal(t, tree.Hash(), root)
+			}
+
+			for i := range 40 {
+				tree, err := NewBinaryTree(sha256.New(), leafValues(i+1), WithScheme(scheme))
+				require.Nil(t, err)
+
+				root, err := b.RootAt(i + 1)
+				require.Nil(t, err)
+				require.Equal(t, tree.Hash(), root)
+			}
+		}
+	})
+}
+
+func TestBinaryTreeBuilder_BinaryTree(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if no leaves have been appended", func(t *testing.T) {
+			b := NewBinaryTreeBuilder(sha256.New())
+
+			tree, err := b.BinaryTree()
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+			require.Nil(t, tree)
+		})
+	})
+
+	t.Run("will be equal to a constructed tree", func(t *testing.T) {
+		for numOfLeafs := 1; numOfLeafs <= 20; numOfLeafs++ {
+			b := newFilledBuilder(t, numOfLeafs, WithScheme(DomainSeparatedScheme))
+
+			tree, err := b.BinaryTree()
+			require.Nil(t, err)
+
+			expected, err := NewBinaryTree(sha256.New(), leafValues(numOfLeafs), WithScheme(DomainSeparatedScheme))
+			require.Nil(t, err)
+			require.Equal(t, expected, tree)
+		}
+	})
+}
+
+func TestBinaryTreeBuilder_Prove(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		b := newFilledBuilder(t, 5)
+
+		t.Run("if the tree size is larger than the tree", func(t *testing.T) {
+			proof, err := b.Prove(0, 6)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, proof)
+		})
+
+		t.Run("if the leaf index is not within the tree size", func(t *testing.T) {
+			proof, err := b.Prove(3, 3)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+			require.Nil(t, proof)
+		})
+	})
+
+	t.Run("will match the proofs of a constructed tree", func(t *testing.T) {
+		b := newFilledBuilder(t, 20)
+
+		for treeSize := 1; treeSize <= 20; treeSize++ {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(treeSize)...)
+			require.Nil(t, err)
+
+			for i := range treeSize {
+				expected, err := tree.Prove(i)
+				require.Nil(t, err)
+
+				proof, err := b.Prove(i, treeSize)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+		}
+	})
+}
+
+func TestBinaryTreeBuilder_ProveConsistency(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		b := newFilledBuilder(t, 5)
+
+		t.Run("if the new size is larger than the tree", func(t *testing.T) {
+			proof, err := b.ProveConsistency(2, 6)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, proof)
+		})
+
+		t.Run("if the old size is larger than the new size", func(t *testing.T) {
+			proof, err := b.ProveConsistency(4, 3)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, proof)
+		})
+	})
+
+	t.Run("will match the proofs of a constructed tree", func(t *testing.T) {
+		b := newFilledBuilder(t, 20)
+
+		for newSize := 1; newSize <= 20; newSize++ {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(newSize)...)
+			require.Nil(t, err)
+
+			for oldSize := 0; oldSize <= newSize; oldSize++ {
+				expected, err := tree.ProveConsistency(oldSize)
+				require.Nil(t, err)
+
+				proof, err := b.ProveConsistency(oldSize, newSize)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+		}
+	})
+}
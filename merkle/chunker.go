@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hash"
+	"io"
+	"iter"
+	"math/bits"
+)
+
+// Default chunk sizes used by a [Chunker], unless different
+// sizes are set with [WithChunkSizes].
+const (
+	DefaultMinChunkSize = 2 << 10
+	DefaultAvgChunkSize = 8 << 10
+	DefaultMaxChunkSize = 64 << 10
+)
+
+// ChunkerOption configures [NewChunker].
+type ChunkerOption interface {
+	applyChunker(*chunkerOptions)
+}
+
+type chunkerOptions struct {
+	minChunkSize int
+	avgChunkSize int
+	maxChunkSize int
+}
+
+type chunkSizesOption chunkerOptions
+
+func (sizes chunkSizesOption) applyChunker(o *chunkerOptions) {
+	*o = chunkerOptions(sizes)
+}
+
+// WithChunkSizes sets the minimum, average and maximum size in bytes of the
+// chunks produced by a [Chunker]. The average size is rounded down to a power of 2.
+func WithChunkSizes(minSize, avgSize, maxSize int) ChunkerOption {
+	return chunkSizesOption{
+		minChunkSize: minSize,
+		avgChunkSize: avgSize,
+		maxChunkSize: maxSize,
+	}
+}
+
+// ErrInvalidChunkSizes is returned by [NewChunker] if the chunk sizes
+// do not satisfy 0 < min <= avg <= max.
+var ErrInvalidChunkSizes = errors.New("invalid chunk sizes")
+
+// Chunker splits the content of a reader into variable sized chunks using the
+// FastCDC content-defined chunking algorithm. Chunk boundaries are determined by
+// the content itself, so inserting or removing bytes only changes the chunks
+// around the edit, instead of every following chunk as with fixed size chunks.
+type Chunker struct {
+	r        io.Reader
+	min, max int
+	avg      int
+
+	// maskS is used before reaching the average chunk size and has more bits
+	// set than maskL, which is used after, so chunk sizes are normalized
+	// around the average.
+	maskS, maskL uint64
+
+	err error
+}
+
+// NewChunker returns a [Chunker] which reads from the given reader.
+// The chunk sizes can be customized with [WithChunkSizes].
+func NewChunker(r io.Reader, opts ...ChunkerOption) (*Chunker, error) {
+	var o chunkerOptions
+	for _, opt := range opts {
+		opt.applyChunker(&o)
+	}
+	if o.minChunkSize == 0 && o.avgChunkSize == 0 && o.maxChunkSize == 0 {
+		o.minChunkSize = DefaultMinChunkSize
+		o.avgChunkSize = DefaultAvgChunkSize
+		o.maxChunkSize = DefaultMaxChunkSize
+	}
+	if o.minChunkSize <= 0 || o.minChunkSize > o.avgChunkSize || o.avgChunkSize > o.maxChunkSize {
+		return nil, ErrInvalidChunkSizes
+	}
+
+	avgBits := bits.Len(uint(o.avgChunkSize)) - 1
+	return &Chunker{
+		r:     r,
+		min:   o.minChunkSize,
+		avg:   1 << avgBits,
+		max:   o.maxChunkSize,
+		maskS: ^uint64(0) << (64 - min(avgBits+1, 64)),
+		maskL: ^uint64(0) << (64 - max(avgBits-1, 0)),
+	}, nil
+}
+
+// Chunks returns an iterator over the chunks of the underlying reader. Each
+// chunk is a newly allocated slice which may be retained by the caller.
+// The iteration stops at the end of the reader or on the first read error,
+// which is then returned by [Chunker.Err].
+func (c *Chunker) Chunks() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		buf := make([]byte, 0, c.max)
+		eof := false
+		for {
+			if !eof && len(buf) < c.max {
+				n, err := io.ReadFull(c.r, buf[len(buf):c.max])
+				buf = buf[:len(buf)+n]
+				if err == io.EOF || err == io.ErrUnexpectedEOF {
+					eof = true
+				} else if err != nil {
+					c.err = err
+					return
+				}
+			}
+			if len(buf) == 0 {
+				return
+			}
+
+			n := c.cut(buf)
+			chunk := bytes.Clone(buf[:n])
+			buf = buf[:copy(buf, buf[n:])]
+			if !yield(chunk) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered while reading chunks, if any.
+func (c *Chunker) Err() error {
+	return c.err
+}
+
+// cut returns the length of the next chunk at the start of the given data.
+func (c *Chunker) cut(data []byte) int {
+	if len(data) <= c.min {
+		return len(data)
+	}
+
+	normal := min(c.avg, len(data))
+	end := min(c.max, len(data))
+
+	var fp uint64
+	i := c.min
+	for ; i < normal; i++ {
+		fp = fp<<1 + gearTable[data[i]]
+		if fp&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < end; i++ {
+		fp = fp<<1 + gearTable[data[i]]
+		if fp&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return end
+}
+
+// gearTable maps every byte to a pseudo random value for the rolling gear hash.
+// It is generated from a fixed seed, so chunk boundaries never change.
+var gearTable = func() (table [256]uint64) {
+	// splitmix64
+	state := uint64(0x5a4c414253)
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// NewChunkedBinaryTree will construct a full merkle [BinaryTree] whose leaves are
+// the content-defined chunks produced by the given [Chunker]. Small edits to the
+// content only change the leaves around the edit, so unchanged chunks can be
+// deduplicated across versions of the content.
+func NewChunkedBinaryTree(hasher hash.Hash, chunker *Chunker, opts ...Option) (*BinaryTree, error) {
+	o := applyOptions(opts)
+
+	var nodes []*BinaryTree
+	for chunk := range chunker.Chunks() {
+		hash, err := o.scheme.hashLeaf(hasher, bytes.NewReader(chunk))
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, &BinaryTree{
+			hash:   hash,
+			size:   1,
+			scheme: o.scheme,
+		})
+	}
+	if err := chunker.Err(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	return constructBinaryTree(context.Background(), newProgressTracker(nil, len(nodes)), o.scheme, hasher, nodes, 1)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomBytes(seed uint64, n int) []byte {
+	r := rand.New(rand.NewPCG(seed, seed))
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(r.Uint32())
+	}
+	return b
+}
+
+func collectChunks(t *testing.T, data []byte, opts ...ChunkerOption) [][]byte {
+	chunker, err := NewChunker(bytes.NewReader(data), opts...)
+	require.Nil(t, err)
+
+	var chunks [][]byte
+	for chunk := range chunker.Chunks() {
+		chunks = append(chunks, chunk)
+	}
+	require.Nil(t, chunker.Err())
+	return chunks
+}
+
+func TestChunker(t *testing.T) {
+	data := randomBytes(1, 1<<20)
+
+	t.Run("will split the content into chunks within the size limits", func(t *testing.T) {
+		chunks := collectChunks(t, data, WithChunkSizes(1<<10, 4<<10, 16<<10))
+		require.Equal(t, data, bytes.Join(chunks, nil))
+
+		var total int
+		for i, chunk := range chunks {
+			total += len(chunk)
+			require.LessOrEqual(t, len(chunk), 16<<10)
+			if i < len(chunks)-1 {
+				require.GreaterOrEqual(t, len(chunk), 1<<10)
+			}
+		}
+
+		// normalized chunking keeps the average close to the requested size
+		avg := total / len(chunks)
+		require.Greater(t, avg, 2<<10)
+		require.Less(t, avg, 8<<10)
+	})
+
+	t.Run("will only change the chunks around an edit", func(t *testing.T) {
+		edited := append([]byte("a small edit"), data...)
+
+		before := collectChunks(t, data)
+		after := collectChunks(t, edited)
+
+		seen := make(map[string]bool, len(before))
+		for _, chunk := range before {
+			seen[string(chunk)] = true
+		}
+
+		var changed int
+		for _, chunk := range after {
+			if !seen[string(chunk)] {
+				changed++
+			}
+		}
+		require.LessOrEqual(t, changed, 2)
+	})
+
+	t.Run("will return a single chunk if the content is smaller than the minimum", func(t *testing.T) {
+		chunks := collectChunks(t, []byte("tiny"))
+		require.Equal(t, [][]byte{[]byte("tiny")}, chunks)
+	})
+
+	t.Run("will not return any chunks if the content is empty", func(t *testing.T) {
+		chunks := collectChunks(t, nil)
+		require.Empty(t, chunks)
+	})
+
+	t.Run("will stop reading if the iteration is stopped", func(t *testing.T) {
+		chunker, err := NewChunker(bytes.NewReader(data))
+		require.Nil(t, err)
+
+		for range chunker.Chunks() {
+			break
+		}
+		require.Nil(t, chunker.Err())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the chunk sizes are invalid", func(t *testing.T) {
+			_, err := NewChunker(bytes.NewReader(data), WithChunkSizes(8, 4, 16))
+			require.ErrorIs(t, err, ErrInvalidChunkSizes)
+
+			_, err = NewChunker(bytes.NewReader(data), WithChunkSizes(0, 4, 16))
+			require.ErrorIs(t, err, ErrInvalidChunkSizes)
+		})
+
+		t.Run("if the reader fails", func(t *testing.T) {
+			r := io.MultiReader(
+				bytes.NewReader(data[:100<<10]),
+				readFunc(func(b []byte) (int, error) {
+					return 0, errReadFailed
+				}),
+			)
+
+			chunker, err := NewChunker(r)
+			require.Nil(t, err)
+
+			for range chunker.Chunks() {
+			}
+			require.ErrorIs(t, chunker.Err(), errReadFailed)
+		})
+	})
+}
+
+func TestNewChunkedBinaryTree(t *testing.T) {
+	data := randomBytes(2, 256<<10)
+
+	t.Run("will use every chunk as a leaf", func(t *testing.T) {
+		chunks := collectChunks(t, data)
+
+		leafs := make([]io.Reader, len(chunks))
+		for i, chunk := range chunks {
+			leafs[i] = bytes.NewReader(chunk)
+		}
+
+		expected, err := NewBinaryTree(sha256.New(), leafs, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		chunker, err := NewChunker(bytes.NewReader(data))
+		require.Nil(t, err)
+
+		tree, err := NewChunkedBinaryTree(sha256.New(), chunker, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+		require.Equal(t, expected.Hash(), tree.Hash())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the reader is empty", func(t *testing.T) {
+			chunker, err := NewChunker(bytes.NewReader(nil))
+			require.Nil(t, err)
+
+			_, err = NewChunkedBinaryTree(sha256.New(), chunker)
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if the reader fails", func(t *testing.T) {
+			r := readFunc(func(b []byte) (int, error) {
+				return 0, errReadFailed
+			})
+
+			chunker, err := NewChunker(r)
+			require.Nil(t, err)
+
+			_, err = NewChunkedBinaryTree(sha256.New(), chunker)
+			require.ErrorIs(t, err, errReadFailed)
+		})
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+)
+
+// ErrInvalidTreeSize is returned if a tree size is negative or larger
+// than the tree it refers to.
+var ErrInvalidTreeSize = errors.New("invalid tree size")
+
+// ProveConsistency returns a consistency proof which shows that the first oldSize
+// leaves of this tree form a tree whose root hash is that of a previous version
+// of this tree, i.e. this tree only appended leaves to it. The proof is a list
+// of node hashes as described in RFC 6962.
+func (t *BinaryTree) ProveConsistency(oldSize int) ([][]byte, error) {
+	if oldSize < 0 || oldSize > t.size {
+		return nil, ErrInvalidTreeSize
+	}
+	if oldSize == 0 || oldSize == t.size {
+		return [][]byte{}, nil
+	}
+	return subproof(t, oldSize, true), nil
+}
+
+func subproof(t *BinaryTree, m int, complete bool) [][]byte {
+	if m == t.size {
+		if complete {
+			return nil
+		}
+		return [][]byte{t.hash}
+	}
+
+	if m <= t.left.size {
+		return append(subproof(t.left, m, complete), t.right.hash)
+	}
+	return append(subproof(t.right, m-t.left.size, false), t.left.hash)
+}
+
+// VerifyConsistency verifies that the tree with newSize leaves and the given new root
+// hash is an append-only extension of the tree with oldSize leaves and the given old
+// root hash. The hasher and options must be the same as those used to construct the
+// trees. A nil error is only returned if the proof is valid.
+func VerifyConsistency(hasher hash.Hash, oldSize, newSize int, oldRoot, newRoot []byte, proof [][]byte, opts ...Option) error {
+	if oldSize < 0 || oldSize > newSize {
+		return ErrInvalidTreeSize
+	}
+	if oldSize == 0 {
+		// every tree is trivially consistent with the empty tree
+		if len(proof) != 0 {
+			return ErrInvalidProof
+		}
+		return nil
+	}
+	if oldSize == newSize {
+		if len(proof) != 0 {
+			return ErrInvalidProof
+		}
+		if !bytes.Equal(oldRoot, newRoot) {
+			return ErrRootMismatch
+		}
+		return nil
+	}
+	if len(proof) == 0 {
+		return ErrInvalidProof
+	}
+
+	// when the old tree is a complete subtree of the new tree its root
+	// is omitted from the proof since the verifier already knows it
+	if oldSize&(oldSize-1) == 0 {
+		proof = append([][]byte{oldRoot}, proof...)
+	}
+
+	o := applyOptions(opts)
+
+	fn := oldSize - 1
+	sn := newSize - 1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+
+	fr := proof[0]
+	sr := proof[0]
+	var err error
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return ErrInvalidProof
+		}
+
+		if fn&1 == 1 || fn == sn {
+			fr, err = o.scheme.hashChildren(hasher, c, fr)
+			if err != nil {
+				return err
+			}
+			sr, err = o.scheme.hashChildren(hasher, c, sr)
+			if err != nil {
+				return err
+			}
+
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			sr, err = o.scheme.hashChildren(hasher, sr, c)
+			if err != nil {
+				return err
+			}
+		}
+
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return ErrInvalidProof
+	}
+
+	if !bytes.Equal(fr, oldRoot) || !bytes.Equal(sr, newRoot) {
+		return ErrRootMismatch
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryTree_ProveConsistency(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		tree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+		require.Nil(t, err)
+
+		t.Run("if the old size is negative", func(t *testing.T) {
+			proof, err := tree.ProveConsistency(-1)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, proof)
+		})
+
+		t.Run("if the old size is larger than the tree", func(t *testing.T) {
+			proof, err := tree.ProveConsistency(6)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+			require.Nil(t, proof)
+		})
+	})
+
+	t.Run("will produce the expected RFC 6962 proofs", func(t *testing.T) {
+		testCases := []struct {
+			OldSize int
+			NewSize int
+			Proof   []string
+		}{
+			{
+				OldSize: 1,
+				NewSize: 1,
+			},
+			{
+				OldSize: 1,
+				NewSize: 8,
+				Proof: []string{
+					"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+					"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+					"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+				},
+			},
+			{
+				OldSize: 6,
+				NewSize: 8,
+				Proof: []string{
+					"0ebc5d3437fbe2db158b9f126a1d118e308181031d0a949f8dededebc558ef6a",
+					"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+					"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+				},
+			},
+			{
+				OldSize: 2,
+				NewSize: 5,
+				Proof: []string{
+					"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+					"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+				},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run("from "+strconv.Itoa(testCase.OldSize)+" to "+strconv.Itoa(testCase.NewSize), func(t *testing.T) {
+				tree, err := NewBinaryTree(sha256.New(), rfc6962LeafReaders(t, testCase.NewSize), WithScheme(RFC6962Scheme))
+				require.Nil(t, err)
+
+				proof, err := tree.ProveConsistency(testCase.OldSize)
+				require.Nil(t, err)
+				require.Equal(t, decodeHexes(t, testCase.Proof...), proof)
+
+				oldRoot := decodeHexes(t, rfc6962Roots[testCase.OldSize-1])[0]
+				err = VerifyConsistency(sha256.New(), testCase.OldSize, testCase.NewSize, oldRoot, tree.Hash(), proof, WithScheme(RFC6962Scheme))
+				require.Nil(t, err)
+			})
+		}
+	})
+
+	t.Run("will return a proof which can be verified", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			trees := make([]*BinaryTree, 20)
+			for i := range trees {
+				tree, err := NewBinaryTree(sha256.New(), leafValues(i+1), WithScheme(scheme))
+				require.Nil(t, err)
+
+				trees[i] = tree
+			}
+
+			for _, newTree := range trees {
+				for _, oldTree := range trees[:newTree.size] {
+					proof, err := newTree.ProveConsistency(oldTree.size)
+					require.Nil(t, err)
+
+					err = VerifyConsistency(sha256.New(), oldTree.size, newTree.size, oldTree.Hash(), newTree.Hash(), proof, WithScheme(scheme))
+					require.Nil(t, err, "old size %d new size %d", oldTree.size, newTree.size)
+				}
+			}
+		}
+	})
+}
+
+func TestVerifyConsistency(t *testing.T) {
+	oldTree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+	require.Nil(t, err)
+
+	newTree, err := ConstructBinaryTree(sha256.New(), leafValues(11)...)
+	require.Nil(t, err)
+
+	validProof, err := newTree.ProveConsistency(5)
+	require.Nil(t, err)
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the old size is larger than the new size", func(t *testing.T) {
+			err := VerifyConsistency(sha256.New(), 11, 5, newTree.Hash(), oldTree.Hash(), validProof)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+		})
+
+		t.Run("if the sizes are equal but the roots differ", func(t *testing.T) {
+			err := VerifyConsistency(sha256.New(), 5, 5, oldTree.Hash(), newTree.Hash(), nil)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the proof is empty", func(t *testing.T) {
+			err := VerifyConsistency(sha256.New(), 5, 11, oldTree.Hash(), newTree.Hash(), nil)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the proof has too many hashes", func(t *testing.T) {
+			proof := append(validProof[:len(validProof):len(validProof)], newTree.Hash())
+
+			err := VerifyConsistency(sha256.New(), 5, 11, oldTree.Hash(), newTree.Hash(), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the old root does not match", func(t *testing.T) {
+			err := VerifyConsistency(sha256.New(), 5, 11, newTree.Hash(), newTree.Hash(), validProof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if a proof hash has been modified", func(t *testing.T) {
+			proof := make([][]byte, len(validProof))
+			copy(proof, validProof)
+			proof[0] = bytes.Clone(proof[0])
+			proof[0][0] ^= 0xff
+
+			err := VerifyConsistency(sha256.New(), 5, 11, oldTree.Hash(), newTree.Hash(), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the new tree rewrote history", func(t *testing.T) {
+			leafs := leafValues(11)
+			leafs[2] = leafValues(4)[3]
+
+			rewritten, err := ConstructBinaryTree(sha256.New(), leafs...)
+			require.Nil(t, err)
+
+			proof, err := rewritten.ProveConsistency(5)
+			require.Nil(t, err)
+
+			err = VerifyConsistency(sha256.New(), 5, 11, oldTree.Hash(), rewritten.Hash(), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+	})
+}
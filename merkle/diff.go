@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"iter"
+)
+
+// Diff returns the indices, in ascending order, of the leaves which differ
+// between the two trees. Subtrees with identical hashes are never walked,
+// so the cost of Diff is proportional to the number of differing leaves
+// rather than the size of the trees.
+//
+// If the trees contain a different number of leaves, every leaf which is
+// only contained in the larger tree is considered to differ. A nil tree is
+// treated as a tree without any leaves.
+func Diff(a, b *BinaryTree) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		switch {
+		case a == nil && b == nil:
+			return
+		case a == nil:
+			yieldRange(0, b.size, yield)
+		case b == nil:
+			yieldRange(0, a.size, yield)
+		default:
+			diff(a, b, 0, yield)
+		}
+	}
+}
+
+// diff yields every index in [offset, offset+max(a.size, b.size)) whose leaf
+// is either not the same in both trees or only contained in one of them.
+func diff(a, b *BinaryTree, offset int, yield func(int) bool) bool {
+	if a.size > b.size {
+		a, b = b, a
+	}
+	if a.size == b.size && bytes.Equal(a.hash, b.hash) {
+		return true
+	}
+
+	if a.size == b.size {
+		if a.IsLeaf() {
+			return yield(offset)
+		}
+
+		return diff(a.left, b.left, offset, yield) &&
+			diff(a.right, b.right, offset+a.left.size, yield)
+	}
+
+	// the smaller tree fits entirely in the left subtree of the larger tree
+	k := b.left.size
+	if a.size <= k {
+		return diff(a, b.left, offset, yield) &&
+			yieldRange(offset+k, offset+b.size, yield)
+	}
+
+	// otherwise, both trees place the same number of leaves in their left subtree
+	return diff(a.left, b.left, offset, yield) &&
+		diff(a.right, b.right, offset+k, yield)
+}
+
+func yieldRange(start, end int, yield func(int) bool) bool {
+	for i := start; i < end; i++ {
+		if !yield(i) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+func ExampleDiff() {
+	replicaA, err := ConstructBinaryTree(
+		sha256.New(),
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+		strings.NewReader("c"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	replicaB, err := ConstructBinaryTree(
+		sha256.New(),
+		strings.NewReader("a"),
+		strings.NewReader("x"),
+		strings.NewReader("c"),
+		strings.NewReader("d"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i := range Diff(replicaA, replicaB) {
+		fmt.Println(i)
+	}
+
+	// Output: 1
+	// 3
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	construct := func(t *testing.T, leafs []*strings.Reader) *BinaryTree {
+		tree, err := ConstructBinaryTree(sha256.New(), leafs...)
+		require.Nil(t, err)
+		return tree
+	}
+
+	withChanges := func(numOfLeafs int, changed ...int) []*strings.Reader {
+		leafs := leafValues(numOfLeafs)
+		for _, i := range changed {
+			leafs[i] = strings.NewReader("changed")
+		}
+		return leafs
+	}
+
+	testCases := []struct {
+		Name     string
+		A        func(*testing.T) *BinaryTree
+		B        func(*testing.T) *BinaryTree
+		Expected []int
+	}{
+		{
+			Name: "will not yield anything if the trees are identical",
+			A: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(13))
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(13))
+			},
+		},
+		{
+			Name: "will not yield anything if both trees are nil",
+			A: func(t *testing.T) *BinaryTree {
+				return nil
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return nil
+			},
+		},
+		{
+			Name: "will yield every changed leaf",
+			A: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(13))
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return construct(t, withChanges(13, 0, 5, 6, 12))
+			},
+			Expected: []int{0, 5, 6, 12},
+		},
+		{
+			Name: "will yield every leaf only contained in the larger tree",
+			A: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(5))
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(11))
+			},
+			Expected: []int{5, 6, 7, 8, 9, 10},
+		},
+		{
+			Name: "will yield changed leaves and leaves only contained in the larger tree",
+			A: func(t *testing.T) *BinaryTree {
+				return construct(t, withChanges(11, 1, 9))
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(3))
+			},
+			Expected: []int{1, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			Name: "will yield every leaf if one of the trees is nil",
+			A: func(t *testing.T) *BinaryTree {
+				return nil
+			},
+			B: func(t *testing.T) *BinaryTree {
+				return construct(t, leafValues(3))
+			},
+			Expected: []int{0, 1, 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			a := testCase.A(t)
+			b := testCase.B(t)
+
+			require.Equal(t, testCase.Expected, slices.Collect(Diff(a, b)))
+			require.Equal(t, testCase.Expected, slices.Collect(Diff(b, a)))
+		})
+	}
+
+	t.Run("will match a leaf by leaf comparison", func(t *testing.T) {
+		for sizeA := 1; sizeA <= 20; sizeA++ {
+			for sizeB := 1; sizeB <= 20; sizeB++ {
+				a := construct(t, withChanges(sizeA, sizeA/2))
+				b := construct(t, leafValues(sizeB))
+
+				var expected []int
+				for i := range max(sizeA, sizeB) {
+					if i >= sizeA || i >= sizeB || i == sizeA/2 {
+						expected = append(expected, i)
+					}
+				}
+
+				require.Equal(t, expected, slices.Collect(Diff(a, b)), "size a %d size b %d", sizeA, sizeB)
+			}
+		}
+	})
+
+	t.Run("will stop when the caller stops iterating", func(t *testing.T) {
+		a := construct(t, leafValues(20))
+		b := construct(t, withChanges(20, 2, 4, 6, 8))
+
+		var seen []int
+		for i := range Diff(a, b) {
+			seen = append(seen, i)
+			if len(seen) == 2 {
+				break
+			}
+		}
+		require.Equal(t, []int{2, 4}, seen)
+	})
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+)
+
+// encodingVersion is the current version of the binary and JSON encodings of a [BinaryTree].
+const encodingVersion = 1
+
+var (
+	// ErrUnsupportedEncodingVersion is returned when decoding a [BinaryTree]
+	// which was encoded with an unknown format version.
+	ErrUnsupportedEncodingVersion = errors.New("unsupported encoding version")
+
+	// ErrMalformedEncoding is returned when decoding a [BinaryTree] from data
+	// which does not describe a valid tree.
+	ErrMalformedEncoding = errors.New("malformed binary tree encoding")
+
+	// ErrNodeHashMismatch is returned by [BinaryTree.Validate] if the hash of an
+	// interior node does not match the hash of its children.
+	ErrNodeHashMismatch = errors.New("node hash does not match its children")
+)
+
+// MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+//
+// The encoding consists of a version byte, the [Scheme], the hash size
+// and number of leaves followed by the hash of every node in pre-order.
+func (t *BinaryTree) MarshalBinary() ([]byte, error) {
+	hashSize := len(t.hash)
+
+	b := make([]byte, 0, 2+2*binary.MaxVarintLen64+(2*t.size-1)*hashSize)
+	b = append(b, encodingVersion, byte(t.scheme))
+	b = binary.AppendUvarint(b, uint64(hashSize))
+	b = binary.AppendUvarint(b, uint64(t.size))
+
+	var appendNode func(*BinaryTree)
+	appendNode = func(node *BinaryTree) {
+		b = append(b, node.hash...)
+		if node.IsLeaf() {
+			return
+		}
+		appendNode(node.left)
+		appendNode(node.right)
+	}
+	appendNode(t)
+
+	return b, nil
+}
+
+// UnmarshalBinaryTree decodes a [BinaryTree] from the encoding produced by
+// [BinaryTree.MarshalBinary] and validates it with [BinaryTree.Validate] using
+// the given hasher, so a tree whose node hashes were tampered with is rejected.
+func UnmarshalBinaryTree(hasher hash.Hash, b []byte) (*BinaryTree, error) {
+	var t BinaryTree
+	err := t.UnmarshalBinary(b)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Validate(hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+// It only checks that the data describes a well formed tree, not that the node
+// hashes are consistent with each other. Use [UnmarshalBinaryTree] to decode
+// data which is not trusted.
+func (t *BinaryTree) UnmarshalBinary(b []byte) error {
+	if len(b) < 2 {
+		return ErrMalformedEncoding
+	}
+	if b[0] != encodingVersion {
+		return ErrUnsupportedEncodingVersion
+	}
+	scheme := Scheme(b[1])
+	if scheme != RawScheme && scheme != DomainSeparatedScheme {
+		return ErrMalformedEncoding
+	}
+	b = b[2:]
+
+	hashSize, n := binary.Uvarint(b)
+	if n <= 0 || hashSize == 0 {
+		return ErrMalformedEncoding
+	}
+	b = b[n:]
+
+	size, n := binary.Uvarint(b)
+	if n <= 0 || size == 0 {
+		return ErrMalformedEncoding
+	}
+	b = b[n:]
+
+	// check the number of nodes before multiplying to avoid overflowing
+	numOfHashes := uint64(len(b)) / hashSize
+	if uint64(len(b))%hashSize != 0 || size > numOfHashes || 2*size-1 != numOfHashes {
+		return ErrMalformedEncoding
+	}
+
+	var readNode func(int) *BinaryTree
+	readNode = func(size int) *BinaryTree {
+		node := &BinaryTree{
+			hash:   bytes.Clone(b[:hashSize]),
+			size:   size,
+			scheme: scheme,
+		}
+		b = b[hashSize:]
+		if size == 1 {
+			return node
+		}
+
+		k := splitPoint(size)
+		node.left = readNode(k)
+		node.right = readNode(size - k)
+		return node
+	}
+
+	*t = *readNode(int(size))
+	return nil
+}
+
+type binaryTreeJSON struct {
+	Version int             `json:"version"`
+	Scheme  Scheme          `json:"scheme"`
+	Root    *binaryNodeJSON `json:"root"`
+}
+
+type binaryNodeJSON struct {
+	Hash  string          `json:"hash"`
+	Left  *binaryNodeJSON `json:"left,omitempty"`
+	Right *binaryNodeJSON `json:"right,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+//
+// Every node is encoded as an object containing its hex encoded hash and,
+// for interior nodes, its left and right children.
+func (t *BinaryTree) MarshalJSON() ([]byte, error) {
+	var toJSON func(*BinaryTree) *binaryNodeJSON
+	toJSON = func(node *BinaryTree) *binaryNodeJSON {
+		n := &binaryNodeJSON{
+			Hash: hex.EncodeToString(node.hash),
+		}
+		if node.IsLeaf() {
+			return n
+		}
+		n.Left = toJSON(node.left)
+		n.Right = toJSON(node.right)
+		return n
+	}
+
+	return json.Marshal(binaryTreeJSON{
+		Version: encodingVersion,
+		Scheme:  t.scheme,
+		Root:    toJSON(t),
+	})
+}
+
+// UnmarshalBinaryTreeJSON decodes a [BinaryTree] from the encoding produced by
+// [BinaryTree.MarshalJSON] and validates it with [BinaryTree.Validate] using
+// the given hasher, so a tree whose node hashes were tampered with is rejected.
+func UnmarshalBinaryTreeJSON(hasher hash.Hash, b []byte) (*BinaryTree, error) {
+	var t BinaryTree
+	err := t.UnmarshalJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.Validate(hasher)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// It only checks that the data describes a well formed tree, not that the node
+// hashes are consistent with each other. Use [UnmarshalBinaryTreeJSON] to decode
+// data which is not trusted.
+func (t *BinaryTree) UnmarshalJSON(b []byte) error {
+	var v binaryTreeJSON
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+	if v.Version != encodingVersion {
+		return ErrUnsupportedEncodingVersion
+	}
+	if v.Root == nil {
+		return ErrMalformedEncoding
+	}
+
+	var hashSize int
+	var fromJSON func(*binaryNodeJSON) (*BinaryTree, error)
+	fromJSON = func(n *binaryNodeJSON) (*BinaryTree, error) {
+		hash, err := hex.DecodeString(n.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if hashSize == 0 {
+			hashSize = len(hash)
+		}
+		if len(hash) == 0 || len(hash) != hashSize {
+			return nil, ErrMalformedEncoding
+		}
+
+		node := &BinaryTree{
+			hash:   hash,
+			size:   1,
+			scheme: v.Scheme,
+		}
+		if n.Left == nil && n.Right == nil {
+			return node, nil
+		}
+		if n.Left == nil || n.Right == nil {
+			return nil, ErrMalformedEncoding
+		}
+
+		node.left, err = fromJSON(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		node.right, err = fromJSON(n.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		node.size = node.left.size + node.right.size
+		if node.left.size != splitPoint(node.size) {
+			return nil, ErrMalformedEncoding
+		}
+		return node, nil
+	}
+
+	root, err := fromJSON(v.Root)
+	if err != nil {
+		return err
+	}
+
+	*t = *root
+	return nil
+}
+
+// Validate checks that the hash of every interior node matches the hash
+// computed from its children using the given hasher. It returns
+// [ErrNodeHashMismatch] if any of them do not.
+func (t *BinaryTree) Validate(hasher hash.Hash) error {
+	if len(t.hash) != hasher.Size() {
+		return ErrNodeHashMismatch
+	}
+	if t.IsLeaf() {
+		return nil
+	}
+
+	err := t.left.Validate(hasher)
+	if err != nil {
+		return err
+	}
+	err = t.right.Validate(hasher)
+	if err != nil {
+		return err
+	}
+
+	hash, err := t.scheme.hashChildren(hasher, t.left.hash, t.right.hash)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, t.hash) {
+		return ErrNodeHashMismatch
+	}
+	return nil
+}
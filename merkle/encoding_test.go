@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryTree_MarshalBinary(t *testing.T) {
+	t.Run("will round trip", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			for numOfLeafs := 1; numOfLeafs <= 17; numOfLeafs++ {
+				tree, err := NewBinaryTree(sha256.New(), leafValues(numOfLeafs), WithScheme(scheme))
+				require.Nil(t, err)
+
+				b, err := tree.MarshalBinary()
+				require.Nil(t, err)
+				require.Len(t, b, 4+(2*numOfLeafs-1)*sha256.Size)
+
+				var decoded BinaryTree
+				err = decoded.UnmarshalBinary(b)
+				require.Nil(t, err)
+				require.Equal(t, tree, &decoded)
+
+				err = decoded.Validate(sha256.New())
+				require.Nil(t, err)
+			}
+		}
+	})
+}
+
+func TestBinaryTree_UnmarshalBinary(t *testing.T) {
+	tree, err := ConstructBinaryTree(sha256.New(), leafValues(3)...)
+	require.Nil(t, err)
+
+	valid, err := tree.MarshalBinary()
+	require.Nil(t, err)
+
+	t.Run("will return an error", func(t *testing.T) {
+		testCases := []struct {
+			Name   string
+			Data   func() []byte
+			Expect error
+		}{
+			{
+				Name: "if the data is empty",
+				Data: func() []byte {
+					return nil
+				},
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name: "if the version is unknown",
+				Data: func() []byte {
+					b := append([]byte{}, valid...)
+					b[0] = 2
+					return b
+				},
+				Expect: ErrUnsupportedEncodingVersion,
+			},
+			{
+				Name: "if the scheme is unknown",
+				Data: func() []byte {
+					b := append([]byte{}, valid...)
+					b[1] = 7
+					return b
+				},
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name: "if the hashes have been truncated",
+				Data: func() []byte {
+					return valid[:len(valid)-1]
+				},
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name: "if there are more hashes than nodes",
+				Data: func() []byte {
+					return append(append([]byte{}, valid...), tree.Hash()...)
+				},
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name: "if the number of leaves is too large",
+				Data: func() []byte {
+					b := append([]byte{}, valid...)
+					b[3] = 100
+					return b
+				},
+				Expect: ErrMalformedEncoding,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				var decoded BinaryTree
+				err := decoded.UnmarshalBinary(testCase.Data())
+				require.ErrorIs(t, err, testCase.Expect)
+			})
+		}
+	})
+}
+
+func TestBinaryTree_MarshalJSON(t *testing.T) {
+	t.Run("will encode hashes as hex", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), []*strings.Reader{strings.NewReader("a")}, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		b, err := json.Marshal(tree)
+		require.Nil(t, err)
+		require.JSONEq(t, `{
+			"version": 1,
+			"scheme": "domain_separated",
+			"root": {"hash": "`+tree.String()+`"}
+		}`, string(b))
+	})
+
+	t.Run("will round trip", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			for numOfLeafs := 1; numOfLeafs <= 17; numOfLeafs++ {
+				tree, err := NewBinaryTree(sha256.New(), leafValues(numOfLeafs), WithScheme(scheme))
+				require.Nil(t, err)
+
+				b, err := json.Marshal(tree)
+				require.Nil(t, err)
+
+				var decoded BinaryTree
+				err = json.Unmarshal(b, &decoded)
+				require.Nil(t, err)
+				require.Equal(t, tree, &decoded)
+
+				err = decoded.Validate(sha256.New())
+				require.Nil(t, err)
+			}
+		}
+	})
+}
+
+func TestBinaryTree_UnmarshalJSON(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		testCases := []struct {
+			Name   string
+			Data   string
+			Expect error
+		}{
+			{
+				Name:   "if the version is unknown",
+				Data:   `{"version": 2, "scheme": "raw", "root": {"hash": "00"}}`,
+				Expect: ErrUnsupportedEncodingVersion,
+			},
+			{
+				Name:   "if the root is missing",
+				Data:   `{"version": 1, "scheme": "raw"}`,
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name:   "if a node only has one child",
+				Data:   `{"version": 1, "scheme": "raw", "root": {"hash": "00", "left": {"hash": "01"}}}`,
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name:   "if the hashes have different sizes",
+				Data:   `{"version": 1, "scheme": "raw", "root": {"hash": "00", "left": {"hash": "01"}, "right": {"hash": "0203"}}}`,
+				Expect: ErrMalformedEncoding,
+			},
+			{
+				Name: "if the tree is not balanced",
+				Data: `{"version": 1, "scheme": "raw", "root": {
+					"hash": "00",
+					"left": {"hash": "01"},
+					"right": {"hash": "02", "left": {"hash": "03"}, "right": {"hash": "04"}}
+				}}`,
+				Expect: ErrMalformedEncoding,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				var decoded BinaryTree
+				err := json.Unmarshal([]byte(testCase.Data), &decoded)
+				require.ErrorIs(t, err, testCase.Expect)
+			})
+		}
+
+		t.Run("if the scheme is unknown", func(t *testing.T) {
+			var decoded BinaryTree
+			err := json.Unmarshal([]byte(`{"version": 1, "scheme": "unknown", "root": {"hash": "00"}}`), &decoded)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestUnmarshalBinaryTree(t *testing.T) {
+	tree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+	require.Nil(t, err)
+
+	valid, err := tree.MarshalBinary()
+	require.Nil(t, err)
+
+	t.Run("will decode a valid tree", func(t *testing.T) {
+		decoded, err := UnmarshalBinaryTree(sha256.New(), valid)
+		require.Nil(t, err)
+		require.Equal(t, tree, decoded)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if a node hash has been modified", func(t *testing.T) {
+			b := bytes.Clone(valid)
+			b[len(b)-1] ^= 0xff
+
+			_, err := UnmarshalBinaryTree(sha256.New(), b)
+			require.ErrorIs(t, err, ErrNodeHashMismatch)
+		})
+
+		t.Run("if the data is malformed", func(t *testing.T) {
+			_, err := UnmarshalBinaryTree(sha256.New(), valid[:len(valid)-1])
+			require.ErrorIs(t, err, ErrMalformedEncoding)
+		})
+	})
+}
+
+func TestUnmarshalBinaryTreeJSON(t *testing.T) {
+	tree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+	require.Nil(t, err)
+
+	valid, err := json.Marshal(tree)
+	require.Nil(t, err)
+
+	t.Run("will decode a valid tree", func(t *testing.T) {
+		decoded, err := UnmarshalBinaryTreeJSON(sha256.New(), valid)
+		require.Nil(t, err)
+		require.Equal(t, tree, decoded)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if a node hash has been modified", func(t *testing.T) {
+			hash := hex.EncodeToString(tree.right.hash)
+			b := bytes.Replace(valid, []byte(hash), []byte(strings.Repeat("0", len(hash))), 1)
+
+			_, err := UnmarshalBinaryTreeJSON(sha256.New(), b)
+			require.ErrorIs(t, err, ErrNodeHashMismatch)
+		})
+
+		t.Run("if the data is malformed", func(t *testing.T) {
+			_, err := UnmarshalBinaryTreeJSON(sha256.New(), []byte(`{"version":1}`))
+			require.ErrorIs(t, err, ErrMalformedEncoding)
+		})
+	})
+}
+
+func TestBinaryTree_Validate(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if an interior node hash has been modified", func(t *testing.T) {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+			require.Nil(t, err)
+
+			tree.left.right.hash[0] ^= 0xff
+
+			err = tree.Validate(sha256.New())
+			require.ErrorIs(t, err, ErrNodeHashMismatch)
+		})
+
+		t.Run("if a different hash function is used", func(t *testing.T) {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(5)...)
+			require.Nil(t, err)
+
+			err = tree.Validate(sha512.New512_256())
+			require.ErrorIs(t, err, ErrNodeHashMismatch)
+		})
+
+		t.Run("if the hash size does not match the hasher", func(t *testing.T) {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(1)...)
+			require.Nil(t, err)
+
+			err = tree.Validate(sha512.New())
+			require.ErrorIs(t, err, ErrNodeHashMismatch)
+		})
+	})
+}
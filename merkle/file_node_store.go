@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sync"
+)
+
+// FileNodeStore is a [NodeStore] which stores fixed size hashes back to back
+// in a single append-only file, so the hash at a position can be read with a
+// single read at a known offset. It is safe for concurrent use.
+type FileNodeStore struct {
+	hashSize int
+
+	mu   sync.RWMutex
+	f    *os.File
+	size uint64
+}
+
+// ErrInvalidHashSize is returned if a [FileNodeStore] is opened with a hash size
+// which is not positive or if a hash of a different size is appended to it.
+var ErrInvalidHashSize = errors.New("invalid hash size")
+
+// OpenFileNodeStore opens, or creates if it does not exist, the file with the given
+// name as a [FileNodeStore] containing hashes of the given size in bytes, which is
+// usually the Size of the [hash.Hash] used to compute them.
+func OpenFileNodeStore(name string, hashSize int) (*FileNodeStore, error) {
+	if hashSize <= 0 {
+		return nil, ErrInvalidHashSize
+	}
+
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	// a partially written hash means the file was not written by
+	// a FileNodeStore or the last append was interrupted
+	if info.Size()%int64(hashSize) != 0 {
+		_ = f.Close()
+		return nil, ErrInvalidNodeStore
+	}
+
+	return &FileNodeStore{
+		hashSize: hashSize,
+		f:        f,
+		size:     uint64(info.Size() / int64(hashSize)),
+	}, nil
+}
+
+// Node implements the [NodeStore] interface.
+func (s *FileNodeStore) Node(pos uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if pos >= s.size {
+		return nil, ErrNodeNotFound
+	}
+
+	hash := make([]byte, s.hashSize)
+	_, err := s.f.ReadAt(hash, int64(pos)*int64(s.hashSize))
+	if err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+// Append implements the [NodeStore] interface. Every hash must have
+// the size the store was opened with.
+func (s *FileNodeStore) Append(hashes ...[]byte) error {
+	var buf bytes.Buffer
+	for _, hash := range hashes {
+		if len(hash) != s.hashSize {
+			return ErrInvalidHashSize
+		}
+		buf.Write(hash)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.f.WriteAt(buf.Bytes(), int64(s.size)*int64(s.hashSize))
+	if err != nil {
+		return err
+	}
+
+	s.size += uint64(len(hashes))
+	return nil
+}
+
+// Size implements the [NodeStore] interface.
+func (s *FileNodeStore) Size() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.size, nil
+}
+
+// Sync commits every appended hash to stable storage.
+func (s *FileNodeStore) Sync() error {
+	return s.f.Sync()
+}
+
+// Close implements the [io.Closer] interface.
+func (s *FileNodeStore) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileNodeStore(t *testing.T) {
+	t.Run("will read back appended hashes", func(t *testing.T) {
+		store, err := OpenFileNodeStore(filepath.Join(t.TempDir(), "nodes"), 4)
+		require.Nil(t, err)
+		defer store.Close()
+
+		err = store.Append([]byte("aaaa"), []byte("bbbb"))
+		require.Nil(t, err)
+		err = store.Append([]byte("cccc"))
+		require.Nil(t, err)
+		require.Nil(t, store.Sync())
+
+		size, err := store.Size()
+		require.Nil(t, err)
+		require.Equal(t, uint64(3), size)
+
+		for pos, expected := range []string{"aaaa", "bbbb", "cccc"} {
+			hash, err := store.Node(uint64(pos))
+			require.Nil(t, err)
+			require.Equal(t, []byte(expected), hash)
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the hash size is not positive", func(t *testing.T) {
+			_, err := OpenFileNodeStore(filepath.Join(t.TempDir(), "nodes"), 0)
+			require.ErrorIs(t, err, ErrInvalidHashSize)
+		})
+
+		t.Run("if the file contains a partial hash", func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "nodes")
+			err := os.WriteFile(name, []byte("aaaab"), 0o644)
+			require.Nil(t, err)
+
+			_, err = OpenFileNodeStore(name, 4)
+			require.ErrorIs(t, err, ErrInvalidNodeStore)
+		})
+
+		t.Run("if a hash has the wrong size", func(t *testing.T) {
+			store, err := OpenFileNodeStore(filepath.Join(t.TempDir(), "nodes"), 4)
+			require.Nil(t, err)
+			defer store.Close()
+
+			err = store.Append([]byte("aaaa"), []byte("bb"))
+			require.ErrorIs(t, err, ErrInvalidHashSize)
+
+			size, err := store.Size()
+			require.Nil(t, err)
+			require.Equal(t, uint64(0), size)
+		})
+
+		t.Run("if no hash is stored at the position", func(t *testing.T) {
+			store, err := OpenFileNodeStore(filepath.Join(t.TempDir(), "nodes"), 4)
+			require.Nil(t, err)
+			defer store.Close()
+
+			_, err = store.Node(0)
+			require.ErrorIs(t, err, ErrNodeNotFound)
+		})
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// keccak256 is a minimal implementation of the legacy Keccak-256 hash function
+// used by Ethereum, which is only needed for testing the PatriciaTrie against
+// known Ethereum test vectors.
+type keccak256 struct {
+	buf []byte
+}
+
+func newKeccak256() hash.Hash {
+	return &keccak256{}
+}
+
+const keccakRate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+func (k *keccak256) Write(p []byte) (int, error) {
+	k.buf = append(k.buf, p...)
+	return len(p), nil
+}
+
+func (k *keccak256) Sum(b []byte) []byte {
+	msg := append([]byte{}, k.buf...)
+	msg = append(msg, 0x01)
+	for len(msg)%keccakRate != 0 {
+		msg = append(msg, 0)
+	}
+	msg[len(msg)-1] |= 0x80
+
+	var state [25]uint64
+	for len(msg) > 0 {
+		for i := range keccakRate / 8 {
+			state[i] ^= binary.LittleEndian.Uint64(msg[8*i:])
+		}
+		keccakF1600(&state)
+		msg = msg[keccakRate:]
+	}
+
+	for i := range 4 {
+		b = binary.LittleEndian.AppendUint64(b, state[i])
+	}
+	return b
+}
+
+func (k *keccak256) Reset()         { k.buf = k.buf[:0] }
+func (k *keccak256) Size() int      { return 32 }
+func (k *keccak256) BlockSize() int { return keccakRate }
+
+func keccakF1600(a *[25]uint64) {
+	for _, rc := range keccakRoundConstants {
+		var c [5]uint64
+		for x := range 5 {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := range 5 {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		var b [25]uint64
+		for x := range 5 {
+			for y := range 5 {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], keccakRotations[x+5*y])
+			}
+		}
+
+		for x := range 5 {
+			for y := 0; y < 25; y += 5 {
+				a[y+x] = b[y+x] ^ (^b[y+(x+1)%5] & b[y+(x+2)%5])
+			}
+		}
+		a[0] ^= rc
+	}
+}
+
+func TestKeccak256(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    []byte
+		Expected string
+	}{
+		{
+			Name:     "empty input",
+			Input:    nil,
+			Expected: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		},
+		{
+			Name:     "empty rlp string",
+			Input:    []byte{0x80},
+			Expected: "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			h := newKeccak256()
+			h.Write(testCase.Input)
+			require.Equal(t, testCase.Expected, hex.EncodeToString(h.Sum(nil)))
+		})
+	}
+}
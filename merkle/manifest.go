@@ -0,0 +1,411 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/z5labs/sdk-go/try"
+)
+
+// DefaultBlockSize is the size of the blocks files are split into by [HashFS],
+// unless a different size is set with [WithBlockSize].
+const DefaultBlockSize = 1 << 20
+
+// HashFSOption configures [HashFS]. Every [Option] is also a HashFSOption.
+type HashFSOption interface {
+	applyHashFS(*hashFSOptions)
+}
+
+type hashFSOptions struct {
+	options
+	blockSize int
+}
+
+type blockSizeOption int
+
+func (n blockSizeOption) applyHashFS(o *hashFSOptions) {
+	o.blockSize = int(n)
+}
+
+// WithBlockSize sets the size in bytes of the blocks files are split into by [HashFS].
+func WithBlockSize(n int) HashFSOption {
+	return blockSizeOption(n)
+}
+
+// ErrUnsupportedFileMode is returned by [HashFS] if it encounters
+// anything other than a regular file or directory, e.g. a symlink.
+var ErrUnsupportedFileMode = errors.New("unsupported file mode")
+
+// Manifest describes the content of a file system as hashed by [HashFS].
+type Manifest struct {
+	// Scheme is the [Scheme] used to compute every hash in the manifest.
+	Scheme Scheme
+
+	// BlockSize is the size of the blocks files were split into.
+	BlockSize int
+
+	// Root is the root hash of the file system.
+	Root []byte
+
+	// Entries contains every file and directory, except the root directory,
+	// in the order they were walked i.e. sorted by path with every directory
+	// directly followed by its content.
+	Entries []ManifestEntry
+}
+
+// ManifestEntry describes a single file or directory in a [Manifest].
+type ManifestEntry struct {
+	// Path is the slash separated path of the entry relative to the root.
+	Path string
+
+	// Mode contains the type and permission bits of the entry.
+	Mode fs.FileMode
+
+	// Size is the size of a file in bytes and always 0 for directories.
+	Size int64
+
+	// Root is the root hash of the tree over the blocks of a file or
+	// the entries of a directory.
+	Root []byte
+
+	// Blocks contains the leaf hash of every block of a file and
+	// is always empty for directories.
+	Blocks [][]byte
+}
+
+// HashFS walks the given file system and computes a reproducible root hash for it,
+// along with a [Manifest] describing every file and directory it contains.
+//
+// Each file is split into fixed size blocks, see [WithBlockSize], which are the leaves
+// of a per-file tree. Each directory is a tree whose leaves are the name, mode and root
+// hash of its entries, sorted by name. The root hash of an empty file or directory is
+// the hash of no data. Only regular files and directories are supported.
+func HashFS(hasher hash.Hash, fsys fs.FS, opts ...HashFSOption) (*Manifest, error) {
+	var o hashFSOptions
+	for _, opt := range opts {
+		opt.applyHashFS(&o)
+	}
+	if o.blockSize <= 0 {
+		o.blockSize = DefaultBlockSize
+	}
+
+	h := &fsHasher{
+		hasher: hasher,
+		fsys:   fsys,
+		manifest: &Manifest{
+			Scheme:    o.scheme,
+			BlockSize: o.blockSize,
+		},
+		block: make([]byte, o.blockSize),
+	}
+
+	root, err := h.hashDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	h.manifest.Root = root
+	return h.manifest, nil
+}
+
+type fsHasher struct {
+	hasher   hash.Hash
+	fsys     fs.FS
+	manifest *Manifest
+	block    []byte
+}
+
+func (h *fsHasher) hashDir(name string) ([]byte, error) {
+	entries, err := fs.ReadDir(h.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	leafs := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		p := path.Join(name, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		mode := info.Mode() & (fs.ModeType | fs.ModePerm)
+
+		i := len(h.manifest.Entries)
+		h.manifest.Entries = append(h.manifest.Entries, ManifestEntry{
+			Path: p,
+			Mode: mode,
+		})
+
+		var root []byte
+		switch {
+		case mode.IsDir():
+			root, err = h.hashDir(p)
+		case mode.IsRegular():
+			root, err = h.hashFile(i, p)
+		default:
+			err = &fs.PathError{Op: "hash", Path: p, Err: ErrUnsupportedFileMode}
+		}
+		if err != nil {
+			return nil, err
+		}
+		h.manifest.Entries[i].Root = root
+
+		leaf, err := h.manifest.Scheme.hashLeaf(h.hasher, bytes.NewReader(dirEntryLeaf(entry.Name(), mode, root)))
+		if err != nil {
+			return nil, err
+		}
+		leafs = append(leafs, leaf)
+	}
+
+	return rootOfLeafHashes(h.hasher, h.manifest.Scheme, leafs)
+}
+
+func (h *fsHasher) hashFile(i int, name string) (root []byte, err error) {
+	f, err := h.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer try.Close(&err, f)
+
+	entry := &h.manifest.Entries[i]
+	for {
+		n, err := io.ReadFull(f, h.block)
+		if n > 0 {
+			leaf, err := h.manifest.Scheme.hashLeaf(h.hasher, bytes.NewReader(h.block[:n]))
+			if err != nil {
+				return nil, err
+			}
+			entry.Blocks = append(entry.Blocks, leaf)
+			entry.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rootOfLeafHashes(h.hasher, h.manifest.Scheme, entry.Blocks)
+}
+
+// dirEntryLeaf encodes the name, mode and root hash of a directory entry
+// as the leaf value of its directory tree.
+func dirEntryLeaf(name string, mode fs.FileMode, root []byte) []byte {
+	b := make([]byte, 0, binary.MaxVarintLen64+len(name)+4+len(root))
+	b = binary.AppendUvarint(b, uint64(len(name)))
+	b = append(b, name...)
+	b = binary.BigEndian.AppendUint32(b, uint32(mode))
+	return append(b, root...)
+}
+
+// rootOfLeafHashes returns the root hash of the [BinaryTree] with the given
+// leaf hashes, or the hash of no data if there are no leaf hashes.
+func rootOfLeafHashes(hasher hash.Hash, scheme Scheme, leafs [][]byte) ([]byte, error) {
+	if len(leafs) == 0 {
+		return EmptyTreeHash(hasher), nil
+	}
+
+	nodes := make([]*BinaryTree, len(leafs))
+	for i, leaf := range leafs {
+		nodes[i] = &BinaryTree{
+			hash:   leaf,
+			size:   1,
+			scheme: scheme,
+		}
+	}
+
+	tree, err := constructBinaryTree(context.Background(), newProgressTracker(nil, len(leafs)), scheme, hasher, nodes, 1)
+	if err != nil {
+		return nil, err
+	}
+	return tree.hash, nil
+}
+
+// ManifestChangeKind describes how an entry differs between two manifests.
+type ManifestChangeKind uint8
+
+const (
+	// EntryAdded means the entry is only contained in the manifest being compared to.
+	EntryAdded ManifestChangeKind = iota
+
+	// EntryRemoved means the entry is only contained in the manifest being compared from.
+	EntryRemoved
+
+	// EntryModified means the content or mode of the entry differs.
+	EntryModified
+)
+
+// ManifestChange is a single entry which differs between two manifests.
+type ManifestChange struct {
+	Path string
+	Kind ManifestChangeKind
+
+	// Blocks contains the indexes of every block of a modified file which
+	// differs, including blocks which are only contained in one of the files.
+	Blocks []int
+}
+
+// DiffManifests returns every entry which differs between the from and to manifest,
+// sorted by path. A directory is only reported as modified if its mode changed,
+// since a change to its content is already reported for the entries it contains.
+func DiffManifests(from, to *Manifest) []ManifestChange {
+	fromEntries := make(map[string]bool, len(from.Entries))
+	for _, entry := range from.Entries {
+		fromEntries[entry.Path] = true
+	}
+	toEntries := make(map[string]*ManifestEntry, len(to.Entries))
+	for i := range to.Entries {
+		toEntries[to.Entries[i].Path] = &to.Entries[i]
+	}
+
+	var changes []ManifestChange
+	for _, a := range from.Entries {
+		b, ok := toEntries[a.Path]
+		if !ok {
+			changes = append(changes, ManifestChange{Path: a.Path, Kind: EntryRemoved})
+			continue
+		}
+
+		if a.Mode != b.Mode || (a.Mode.IsRegular() && !bytes.Equal(a.Root, b.Root)) {
+			changes = append(changes, ManifestChange{
+				Path:   a.Path,
+				Kind:   EntryModified,
+				Blocks: diffBlocks(a.Blocks, b.Blocks),
+			})
+		}
+	}
+	for _, b := range to.Entries {
+		if !fromEntries[b.Path] {
+			changes = append(changes, ManifestChange{Path: b.Path, Kind: EntryAdded})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b ManifestChange) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes
+}
+
+func diffBlocks(a, b [][]byte) []int {
+	var blocks []int
+	for i := range max(len(a), len(b)) {
+		if i >= len(a) || i >= len(b) || !bytes.Equal(a[i], b[i]) {
+			blocks = append(blocks, i)
+		}
+	}
+	return blocks
+}
+
+// VerifyFS hashes the given file system, with the same scheme and block size as
+// the given manifest, and returns every entry which differs from the manifest.
+// [ErrRootMismatch] is returned along with the changes if the root hash differs.
+func VerifyFS(hasher hash.Hash, fsys fs.FS, manifest *Manifest) ([]ManifestChange, error) {
+	actual, err := HashFS(hasher, fsys, WithScheme(manifest.Scheme), WithBlockSize(manifest.BlockSize))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffManifests(manifest, actual)
+	if !bytes.Equal(manifest.Root, actual.Root) {
+		return changes, ErrRootMismatch
+	}
+	return changes, nil
+}
+
+type manifestJSON struct {
+	Scheme    Scheme              `json:"scheme"`
+	BlockSize int                 `json:"block_size"`
+	Root      string              `json:"root"`
+	Entries   []manifestEntryJSON `json:"entries"`
+}
+
+type manifestEntryJSON struct {
+	Path   string      `json:"path"`
+	Mode   fs.FileMode `json:"mode"`
+	Size   int64       `json:"size"`
+	Root   string      `json:"root"`
+	Blocks []string    `json:"blocks,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler] interface. Every hash is hex encoded.
+func (m *Manifest) MarshalJSON() ([]byte, error) {
+	v := manifestJSON{
+		Scheme:    m.Scheme,
+		BlockSize: m.BlockSize,
+		Root:      hex.EncodeToString(m.Root),
+		Entries:   make([]manifestEntryJSON, len(m.Entries)),
+	}
+	for i, entry := range m.Entries {
+		v.Entries[i] = manifestEntryJSON{
+			Path: entry.Path,
+			Mode: entry.Mode,
+			Size: entry.Size,
+			Root: hex.EncodeToString(entry.Root),
+		}
+		for _, block := range entry.Blocks {
+			v.Entries[i].Blocks = append(v.Entries[i].Blocks, hex.EncodeToString(block))
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (m *Manifest) UnmarshalJSON(b []byte) error {
+	var v manifestJSON
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+
+	root, err := hex.DecodeString(v.Root)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{
+		Scheme:    v.Scheme,
+		BlockSize: v.BlockSize,
+		Root:      root,
+		Entries:   make([]ManifestEntry, len(v.Entries)),
+	}
+	for i, entry := range v.Entries {
+		root, err := hex.DecodeString(entry.Root)
+		if err != nil {
+			return err
+		}
+
+		manifest.Entries[i] = ManifestEntry{
+			Path: entry.Path,
+			Mode: entry.Mode,
+			Size: entry.Size,
+			Root: root,
+		}
+		for _, block := range entry.Blocks {
+			hash, err := hex.DecodeString(block)
+			if err != nil {
+				return err
+			}
+			manifest.Entries[i].Blocks = append(manifest.Entries[i].Blocks, hash)
+		}
+	}
+
+	*m = manifest
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing/fstest"
+)
+
+func ExampleVerifyFS() {
+	fsys := fstest.MapFS{
+		"release/app":       {Data: []byte("binary v1"), Mode: 0o755},
+		"release/README.md": {Data: []byte("docs"), Mode: 0o644},
+	}
+
+	manifest, err := HashFS(sha256.New(), fsys, WithBlockSize(4))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fsys["release/app"].Data = []byte("binary v2")
+
+	changes, err := VerifyFS(sha256.New(), fsys, manifest)
+	fmt.Println(err)
+	for _, change := range changes {
+		fmt.Println(change.Path, change.Blocks)
+	}
+
+	// Output: computed root does not match expected root
+	// release/app [2]
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"README.md":        {Data: []byte("hello, world"), Mode: 0o644},
+		"bin/app":          {Data: []byte("0123456789abcdef"), Mode: 0o755},
+		"bin/empty":        {Mode: 0o644},
+		"docs/guide/intro": {Data: []byte("intro"), Mode: 0o644},
+		"tmp":              {Mode: fs.ModeDir | 0o755},
+	}
+}
+
+var errCloseFailed = errors.New("failed to close")
+
+// closeFailFS wraps a file system whose regular files always fail to close.
+type closeFailFS struct {
+	fs.FS
+}
+
+func (fsys closeFailFS) Open(name string) (fs.File, error) {
+	f, err := fsys.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.(fs.ReadDirFile); ok {
+		return f, nil
+	}
+	return closeFailFile{f}, nil
+}
+
+type closeFailFile struct {
+	fs.File
+}
+
+func (f closeFailFile) Close() error {
+	return errors.Join(f.File.Close(), errCloseFailed)
+}
+
+func TestHashFS(t *testing.T) {
+	t.Run("will produce a reproducible root", func(t *testing.T) {
+		a, err := HashFS(sha256.New(), testFS(), WithBlockSize(4))
+		require.Nil(t, err)
+
+		b, err := HashFS(sha256.New(), testFS(), WithBlockSize(4))
+		require.Nil(t, err)
+		require.Equal(t, a, b)
+
+		var paths []string
+		for _, entry := range a.Entries {
+			paths = append(paths, entry.Path)
+		}
+		require.Equal(t, []string{"README.md", "bin", "bin/app", "bin/empty", "docs", "docs/guide", "docs/guide/intro", "tmp"}, paths)
+	})
+
+	t.Run("will build a tree over the blocks of every file", func(t *testing.T) {
+		manifest, err := HashFS(sha256.New(), testFS(), WithBlockSize(4), WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		app := manifest.Entries[2]
+		require.Equal(t, "bin/app", app.Path)
+		require.Equal(t, int64(16), app.Size)
+		require.Len(t, app.Blocks, 4)
+
+		leafs := []io.Reader{
+			strings.NewReader("0123"),
+			strings.NewReader("4567"),
+			strings.NewReader("89ab"),
+			strings.NewReader("cdef"),
+		}
+		tree, err := NewBinaryTree(sha256.New(), leafs, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+		require.Equal(t, tree.Hash(), app.Root)
+	})
+
+	t.Run("will build a tree over the entries of every directory", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a": {Data: []byte("a"), Mode: 0o644},
+			"b": {Data: []byte("b"), Mode: 0o600},
+		}
+
+		manifest, err := HashFS(sha256.New(), fsys)
+		require.Nil(t, err)
+
+		leafs := []io.Reader{
+			bytes.NewReader(dirEntryLeaf("a", 0o644, manifest.Entries[0].Root)),
+			bytes.NewReader(dirEntryLeaf("b", 0o600, manifest.Entries[1].Root)),
+		}
+		tree, err := NewBinaryTree(sha256.New(), leafs)
+		require.Nil(t, err)
+		require.Equal(t, tree.Hash(), manifest.Root)
+	})
+
+	t.Run("will hash empty files and directories as no data", func(t *testing.T) {
+		manifest, err := HashFS(sha256.New(), testFS())
+		require.Nil(t, err)
+
+		for _, entry := range manifest.Entries {
+			if entry.Path == "bin/empty" || entry.Path == "tmp" {
+				require.Equal(t, EmptyTreeHash(sha256.New()), entry.Root)
+				require.Empty(t, entry.Blocks)
+			}
+		}
+	})
+
+	t.Run("will include the mode in the root", func(t *testing.T) {
+		a, err := HashFS(sha256.New(), testFS())
+		require.Nil(t, err)
+
+		fsys := testFS()
+		fsys["bin/app"].Mode = 0o644
+
+		b, err := HashFS(sha256.New(), fsys)
+		require.Nil(t, err)
+		require.NotEqual(t, a.Root, b.Root)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the file system contains a symlink", func(t *testing.T) {
+			fsys := testFS()
+			fsys["bin/link"] = &fstest.MapFile{Data: []byte("app"), Mode: fs.ModeSymlink}
+
+			_, err := HashFS(sha256.New(), fsys)
+			require.ErrorIs(t, err, ErrUnsupportedFileMode)
+
+			var pathErr *fs.PathError
+			require.ErrorAs(t, err, &pathErr)
+			require.Equal(t, "bin/link", pathErr.Path)
+		})
+
+		t.Run("if the root does not exist", func(t *testing.T) {
+			fsys, err := fs.Sub(testFS(), "missing")
+			require.Nil(t, err)
+
+			_, err = HashFS(sha256.New(), fsys)
+			require.ErrorIs(t, err, fs.ErrNotExist)
+		})
+
+		t.Run("if a file can not be closed", func(t *testing.T) {
+			_, err := HashFS(sha256.New(), closeFailFS{testFS()})
+			require.ErrorIs(t, err, errCloseFailed)
+		})
+	})
+}
+
+func TestVerifyFS(t *testing.T) {
+	manifest, err := HashFS(sha256.New(), testFS(), WithBlockSize(4))
+	require.Nil(t, err)
+
+	t.Run("will not find any changes if the file system is unchanged", func(t *testing.T) {
+		changes, err := VerifyFS(sha256.New(), testFS(), manifest)
+		require.Nil(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("will pinpoint every change", func(t *testing.T) {
+		fsys := testFS()
+		fsys["bin/app"].Data = []byte("0123XXXX89abcdef!")
+		fsys["docs/guide/intro"].Mode = 0o600
+		fsys["docs/guide/outro"] = &fstest.MapFile{Data: []byte("outro")}
+		delete(fsys, "README.md")
+
+		changes, err := VerifyFS(sha256.New(), fsys, manifest)
+		require.ErrorIs(t, err, ErrRootMismatch)
+		require.Equal(t, []ManifestChange{
+			{Path: "README.md", Kind: EntryRemoved},
+			{Path: "bin/app", Kind: EntryModified, Blocks: []int{1, 4}},
+			{Path: "docs/guide/intro", Kind: EntryModified},
+			{Path: "docs/guide/outro", Kind: EntryAdded},
+		}, changes)
+	})
+}
+
+func TestManifest_MarshalJSON(t *testing.T) {
+	t.Run("will round trip", func(t *testing.T) {
+		manifest, err := HashFS(sha256.New(), testFS(), WithBlockSize(4), WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		b, err := json.Marshal(manifest)
+		require.Nil(t, err)
+
+		var decoded Manifest
+		err = json.Unmarshal(b, &decoded)
+		require.Nil(t, err)
+		require.Equal(t, manifest, &decoded)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if a hash is not hex encoded", func(t *testing.T) {
+			var decoded Manifest
+			err := json.Unmarshal([]byte(`{"scheme":"raw","block_size":4,"root":"zz"}`), &decoded)
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"io"
+	"math/bits"
+	"slices"
+)
+
+// MountainRange is a Merkle Mountain Range, an append-only accumulator made up
+// of a list of perfect binary trees, called mountains, whose roots are called peaks.
+// Appending a leaf only ever adds new nodes, existing nodes are never rewritten,
+// which makes it suitable for storing in an append-only [NodeStore].
+//
+// Nodes are stored in post-order, so the position of a node in the store never
+// changes once it has been appended. The root hash is computed by bagging the
+// peaks from right to left. A MountainRange is not safe for concurrent use.
+type MountainRange struct {
+	hasher hash.Hash
+	scheme Scheme
+	store  NodeStore
+
+	// size is the number of leaves which have been appended.
+	size uint64
+
+	// nodes is the number of nodes which have been appended to the store.
+	nodes uint64
+
+	// frontier holds the peaks, which are all that is needed to append a leaf.
+	frontier frontier
+}
+
+// MountainRangeProof proves a leaf is contained in a [MountainRange]
+// with a specific root hash.
+type MountainRangeProof struct {
+	// LeafIndex is the index of the proven leaf.
+	LeafIndex uint64
+
+	// TreeSize is the number of leaves in the mountain range the proof was generated from.
+	TreeSize uint64
+
+	// Path contains the sibling hashes within the mountain containing the leaf,
+	// ordered from the leaf up to the peak of the mountain.
+	Path [][]byte
+
+	// Peaks contains the hashes of every other peak, ordered from left to right.
+	Peaks [][]byte
+}
+
+// ErrInvalidNodeStore is returned if the nodes in a [NodeStore] do not
+// correspond to a valid [MountainRange] or [StoredTree].
+var ErrInvalidNodeStore = errors.New("invalid node store")
+
+// NewMountainRange returns a [MountainRange] whose nodes are stored in the given store.
+// If the store already contains nodes, e.g. from a previous process, the mountain range
+// continues where it left off. The hasher and options must be the same as those used
+// when the existing nodes were appended.
+func NewMountainRange(hasher hash.Hash, store NodeStore, opts ...Option) (*MountainRange, error) {
+	nodes, err := store.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	size, ok := mountainRangeLeaves(nodes)
+	if !ok {
+		return nil, ErrInvalidNodeStore
+	}
+
+	frontier, err := loadFrontier(store, size)
+	if err != nil {
+		return nil, err
+	}
+
+	o := applyOptions(opts)
+
+	return &MountainRange{
+		hasher:   hasher,
+		scheme:   o.scheme,
+		store:    store,
+		size:     size,
+		nodes:    nodes,
+		frontier: frontier,
+	}, nil
+}
+
+// Size returns the number of leaves which have been appended.
+func (m *MountainRange) Size() uint64 {
+	return m.size
+}
+
+// Append hashes the given leaf and appends it, along with every new interior node
+// it completes, to the store.
+func (m *MountainRange) Append(leaf io.Reader) error {
+	hash, err := m.scheme.hashLeaf(m.hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	frontier, completed, err := m.frontier.append(m.hasher, m.scheme, hash)
+	if err != nil {
+		return err
+	}
+
+	err = m.store.Append(completed...)
+	if err != nil {
+		return err
+	}
+
+	m.frontier = frontier
+	m.size += 1
+	m.nodes += uint64(len(completed))
+	return nil
+}
+
+// loadFrontier loads the frontier of the first size leaves from a store whose
+// nodes are laid out in post-order, where the frontier hashes are the peaks.
+func loadFrontier(store NodeStore, size uint64) (frontier, error) {
+	peaks := mountainRangePeaks(size)
+	f := frontier{
+		hashes: make([][]byte, len(peaks)),
+		size:   size,
+	}
+	for i, peak := range peaks {
+		hash, err := store.Node(peak.pos)
+		if err != nil {
+			return frontier{}, err
+		}
+		f.hashes[i] = hash
+	}
+	return f, nil
+}
+
+// Root returns the root hash of the mountain range containing every appended leaf.
+func (m *MountainRange) Root() ([]byte, error) {
+	return m.RootAt(m.size)
+}
+
+// RootAt returns the root hash the mountain range had when it contained the
+// given number of leaves.
+func (m *MountainRange) RootAt(size uint64) ([]byte, error) {
+	if size == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+	if size > m.size {
+		return nil, ErrInvalidTreeSize
+	}
+
+	f, err := loadFrontier(m.store, size)
+	if err != nil {
+		return nil, err
+	}
+	return f.root(m.hasher, m.scheme)
+}
+
+// Prove returns a [MountainRangeProof] for the leaf at the given index in the
+// mountain range as it was when it contained treeSize leaves.
+func (m *MountainRange) Prove(leafIndex, treeSize uint64) (*MountainRangeProof, error) {
+	if treeSize == 0 || treeSize > m.size {
+		return nil, ErrInvalidTreeSize
+	}
+	if leafIndex >= treeSize {
+		return nil, ErrLeafIndexOutOfRange
+	}
+
+	proof := &MountainRangeProof{
+		LeafIndex: leafIndex,
+		TreeSize:  treeSize,
+	}
+
+	peaks := mountainRangePeaks(treeSize)
+	i := findPeak(peaks, leafIndex)
+	for j, peak := range peaks {
+		if j == i {
+			continue
+		}
+
+		hash, err := m.store.Node(peak.pos)
+		if err != nil {
+			return nil, err
+		}
+		proof.Peaks = append(proof.Peaks, hash)
+	}
+
+	peak := peaks[i]
+	idx := leafIndex - peak.start
+	pos := peak.pos - (2<<peak.height - 2) + mountainRangeLeafPos(idx)
+	for height := range peak.height {
+		sibling, parent := pos+(2<<height-1), pos+(2<<height)
+		if idx>>height&1 == 1 {
+			sibling, parent = pos-(2<<height-1), pos+1
+		}
+
+		hash, err := m.store.Node(sibling)
+		if err != nil {
+			return nil, err
+		}
+		proof.Path = append(proof.Path, hash)
+		pos = parent
+	}
+	return proof, nil
+}
+
+// VerifyMountainRangeInclusion verifies that the given leaf is contained in the
+// mountain range with the given root hash. The hasher and options must be the same
+// as those used to construct the mountain range. A nil error is only returned if
+// the proof is valid.
+func VerifyMountainRangeInclusion(hasher hash.Hash, root []byte, leaf io.Reader, proof *MountainRangeProof, opts ...Option) error {
+	if proof == nil || proof.LeafIndex >= proof.TreeSize {
+		return ErrInvalidProof
+	}
+
+	peaks := mountainRangePeaks(proof.TreeSize)
+	i := findPeak(peaks, proof.LeafIndex)
+	peak := peaks[i]
+	if len(proof.Path) != peak.height || len(proof.Peaks) != len(peaks)-1 {
+		return ErrInvalidProof
+	}
+
+	o := applyOptions(opts)
+
+	hash, err := o.scheme.hashLeaf(hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	idx := proof.LeafIndex - peak.start
+	for height, sibling := range proof.Path {
+		if idx>>height&1 == 1 {
+			hash, err = o.scheme.hashChildren(hasher, sibling, hash)
+		} else {
+			hash, err = o.scheme.hashChildren(hasher, hash, sibling)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	f := frontier{
+		hashes: slices.Insert(slices.Clone(proof.Peaks), i, hash),
+		size:   proof.TreeSize,
+	}
+	computed, err := f.root(hasher, o.scheme)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(computed, root) {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+type mountainRangePeak struct {
+	// pos is the position of the peak node.
+	pos uint64
+
+	// height is the height of the mountain, where a single leaf has height 0.
+	height int
+
+	// start is the index of the first leaf in the mountain.
+	start uint64
+}
+
+// mountainRangePeaks returns the peaks, from left to right, of a
+// mountain range containing the given number of leaves.
+func mountainRangePeaks(size uint64) []mountainRangePeak {
+	var peaks []mountainRangePeak
+	var offset, start uint64
+	for height := bits.Len64(size) - 1; height >= 0; height-- {
+		if size>>height&1 == 0 {
+			continue
+		}
+
+		peaks = append(peaks, mountainRangePeak{
+			pos:    offset + 2<<height - 2,
+			height: height,
+			start:  start,
+		})
+		offset += 2<<height - 1
+		start += 1 << height
+	}
+	return peaks
+}
+
+// findPeak returns the index of the peak whose mountain contains the given leaf.
+func findPeak(peaks []mountainRangePeak, leafIndex uint64) int {
+	for i, peak := range peaks {
+		if leafIndex < peak.start+1<<peak.height {
+			return i
+		}
+	}
+	return len(peaks) - 1
+}
+
+// mountainRangeLeafPos returns the position of the leaf at the given index.
+func mountainRangeLeafPos(leafIndex uint64) uint64 {
+	return 2*leafIndex - uint64(bits.OnesCount64(leafIndex))
+}
+
+// mountainRangeLeaves returns the number of leaves in a mountain range made
+// up of the given number of nodes, or false if no such mountain range exists.
+func mountainRangeLeaves(nodes uint64) (uint64, bool) {
+	var leaves uint64
+	for height := bits.Len64(nodes) - 1; height >= 0; height-- {
+		size := uint64(2)<<height - 1
+		if size > nodes {
+			continue
+		}
+
+		nodes -= size
+		leaves += 1 << height
+	}
+	return leaves, nodes == 0
+}
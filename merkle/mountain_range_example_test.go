@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+func ExampleMountainRange() {
+	store := NewMemoryNodeStore()
+
+	m, err := NewMountainRange(sha256.New(), store, WithScheme(DomainSeparatedScheme))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, event := range []string{"created", "updated", "deleted"} {
+		err := m.Append(strings.NewReader(event))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	root, err := m.Root()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	proof, err := m.Prove(1, m.Size())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = VerifyMountainRangeInclusion(sha256.New(), root, strings.NewReader("updated"), proof, WithScheme(DomainSeparatedScheme))
+	fmt.Println(err)
+
+	nodes, _ := store.Size()
+	fmt.Println(nodes)
+
+	// Output: <nil>
+	// 4
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildMountainRange(t *testing.T, store NodeStore, n int) *MountainRange {
+	m, err := NewMountainRange(sha256.New(), store, WithScheme(DomainSeparatedScheme))
+	require.Nil(t, err)
+
+	for _, leaf := range leafValues(n) {
+		err := m.Append(leaf)
+		require.Nil(t, err)
+	}
+	return m
+}
+
+func TestMountainRange_Append(t *testing.T) {
+	t.Run("will store nodes in post-order", func(t *testing.T) {
+		store := NewMemoryNodeStore()
+		m := buildMountainRange(t, store, 4)
+		require.Equal(t, uint64(4), m.Size())
+
+		size, err := store.Size()
+		require.Nil(t, err)
+		require.Equal(t, uint64(7), size)
+
+		node := func(pos uint64) []byte {
+			hash, err := store.Node(pos)
+			require.Nil(t, err)
+			return hash
+		}
+
+		hasher := sha256.New()
+		parent := func(left, right []byte) []byte {
+			hash, err := DomainSeparatedScheme.hashChildren(hasher, left, right)
+			require.Nil(t, err)
+			return hash
+		}
+		require.Equal(t, parent(node(0), node(1)), node(2))
+		require.Equal(t, parent(node(3), node(4)), node(5))
+		require.Equal(t, parent(node(2), node(5)), node(6))
+
+		root, err := m.Root()
+		require.Nil(t, err)
+		require.Equal(t, node(6), root)
+	})
+
+	t.Run("will never rewrite existing nodes", func(t *testing.T) {
+		store := NewMemoryNodeStore()
+		m := buildMountainRange(t, store, 11)
+
+		var before [][]byte
+		for pos := range uint64(19) {
+			hash, err := store.Node(pos)
+			require.Nil(t, err)
+			before = append(before, bytes.Clone(hash))
+		}
+
+		for i := range 20 {
+			err := m.Append(strings.NewReader("more" + strconv.Itoa(i)))
+			require.Nil(t, err)
+		}
+
+		for pos, hash := range before {
+			after, err := store.Node(uint64(pos))
+			require.Nil(t, err)
+			require.Equal(t, hash, after)
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the leaf fails to be read", func(t *testing.T) {
+			m := buildMountainRange(t, NewMemoryNodeStore(), 1)
+
+			err := m.Append(readFunc(func(b []byte) (int, error) {
+				return 0, errReadFailed
+			}))
+			require.ErrorIs(t, err, errReadFailed)
+			require.Equal(t, uint64(1), m.Size())
+		})
+
+		t.Run("if the store fails to append", func(t *testing.T) {
+			errAppendFailed := errors.New("failed to append")
+			store := failingNodeStore{
+				NodeStore: NewMemoryNodeStore(),
+				err:       errAppendFailed,
+			}
+
+			m, err := NewMountainRange(sha256.New(), store)
+			require.Nil(t, err)
+
+			err = m.Append(strings.NewReader("a"))
+			require.ErrorIs(t, err, errAppendFailed)
+			require.Equal(t, uint64(0), m.Size())
+		})
+	})
+}
+
+type failingNodeStore struct {
+	NodeStore
+	err error
+}
+
+func (s failingNodeStore) Append(hashes ...[]byte) error {
+	return s.err
+}
+
+func TestNewMountainRange(t *testing.T) {
+	t.Run("will continue from an existing store", func(t *testing.T) {
+		store := NewMemoryNodeStore()
+		full := buildMountainRange(t, NewMemoryNodeStore(), 10)
+		partial := buildMountainRange(t, store, 6)
+
+		resumed, err := NewMountainRange(sha256.New(), store, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+		require.Equal(t, partial.Size(), resumed.Size())
+
+		for _, leaf := range leafValues(10)[6:] {
+			err := resumed.Append(leaf)
+			require.Nil(t, err)
+		}
+
+		expected, err := full.Root()
+		require.Nil(t, err)
+
+		root, err := resumed.Root()
+		require.Nil(t, err)
+		require.Equal(t, expected, root)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the store does not contain a valid mountain range", func(t *testing.T) {
+			store := NewMemoryNodeStore()
+			err := store.Append([]byte("a"), []byte("b"))
+			require.Nil(t, err)
+
+			m, err := NewMountainRange(sha256.New(), store)
+			require.ErrorIs(t, err, ErrInvalidNodeStore)
+			require.Nil(t, m)
+		})
+	})
+}
+
+func TestMountainRange_RootAt(t *testing.T) {
+	t.Run("will return the root of a previous size", func(t *testing.T) {
+		m, err := NewMountainRange(sha256.New(), NewMemoryNodeStore())
+		require.Nil(t, err)
+
+		var roots [][]byte
+		for _, leaf := range leafValues(17) {
+			err := m.Append(leaf)
+			require.Nil(t, err)
+
+			root, err := m.Root()
+			require.Nil(t, err)
+			roots = append(roots, root)
+		}
+
+		for i, root := range roots {
+			old, err := m.RootAt(uint64(i + 1))
+			require.Nil(t, err)
+			require.Equal(t, root, old)
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		m := buildMountainRange(t, NewMemoryNodeStore(), 3)
+
+		t.Run("if the size is zero", func(t *testing.T) {
+			_, err := m.RootAt(0)
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if the size is larger than the mountain range", func(t *testing.T) {
+			_, err := m.RootAt(4)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+		})
+	})
+}
+
+func TestMountainRange_Prove(t *testing.T) {
+	m := buildMountainRange(t, NewMemoryNodeStore(), 23)
+
+	t.Run("will verify every leaf at every size", func(t *testing.T) {
+		for size := uint64(1); size <= m.Size(); size++ {
+			root, err := m.RootAt(size)
+			require.Nil(t, err)
+
+			for i := range size {
+				proof, err := m.Prove(i, size)
+				require.Nil(t, err)
+
+				leaf := strings.NewReader(strconv.FormatUint(i, 10))
+				err = VerifyMountainRangeInclusion(sha256.New(), root, leaf, proof, WithScheme(DomainSeparatedScheme))
+				require.Nil(t, err, "size %d, index %d", size, i)
+			}
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the size is invalid", func(t *testing.T) {
+			_, err := m.Prove(0, 0)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+
+			_, err = m.Prove(0, 24)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+		})
+
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			_, err := m.Prove(10, 10)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+	})
+}
+
+func TestVerifyMountainRangeInclusion(t *testing.T) {
+	m := buildMountainRange(t, NewMemoryNodeStore(), 11)
+	root, err := m.Root()
+	require.Nil(t, err)
+
+	prove := func(t *testing.T, leafIndex uint64) *MountainRangeProof {
+		proof, err := m.Prove(leafIndex, m.Size())
+		require.Nil(t, err)
+		return proof
+	}
+
+	verify := func(leaf string, proof *MountainRangeProof) error {
+		return VerifyMountainRangeInclusion(sha256.New(), root, strings.NewReader(leaf), proof, WithScheme(DomainSeparatedScheme))
+	}
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is nil", func(t *testing.T) {
+			require.ErrorIs(t, verify("1", nil), ErrInvalidProof)
+		})
+
+		t.Run("if the leaf is different", func(t *testing.T) {
+			require.ErrorIs(t, verify("2", prove(t, 1)), ErrRootMismatch)
+		})
+
+		t.Run("if the leaf index is different", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.LeafIndex = 0
+			require.ErrorIs(t, verify("1", proof), ErrRootMismatch)
+		})
+
+		t.Run("if the path has the wrong length", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.Path = proof.Path[1:]
+			require.ErrorIs(t, verify("1", proof), ErrInvalidProof)
+		})
+
+		t.Run("if a peak is missing", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.Peaks = proof.Peaks[1:]
+			require.ErrorIs(t, verify("1", proof), ErrInvalidProof)
+		})
+
+		t.Run("if the tree size is different", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.TreeSize = 12
+			require.ErrorIs(t, verify("1", proof), ErrInvalidProof)
+		})
+	})
+}
+
+func TestMountainRangeLeaves(t *testing.T) {
+	nodes := uint64(0)
+	for leaves := range uint64(100) {
+		n, ok := mountainRangeLeaves(nodes)
+		require.True(t, ok)
+		require.Equal(t, leaves, n)
+
+		for pos := nodes + 1; pos < mountainRangeLeafPos(leaves+1); pos++ {
+			_, ok := mountainRangeLeaves(pos)
+			require.False(t, ok, pos)
+		}
+		nodes = mountainRangeLeafPos(leaves + 1)
+	}
+}
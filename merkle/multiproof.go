@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"hash"
+	"io"
+	"slices"
+)
+
+// MultiProof proves that a batch of leaves are contained in a [BinaryTree]
+// with a specific root hash. Unlike a set of [InclusionProof], every node
+// hash is contained at most once and hashes which can be computed from the
+// proven leaves themselves are omitted entirely.
+type MultiProof struct {
+	// LeafIndices contains the index of every proven leaf in ascending order.
+	LeafIndices []int
+
+	// TreeSize is the number of leaves in the tree the proof was generated from.
+	TreeSize int
+
+	// Hashes contains the hash of every subtree which does not contain any of
+	// the proven leaves but is a sibling of one that does, ordered as they are
+	// visited by a depth first, left to right, traversal of the tree.
+	Hashes [][]byte
+}
+
+// ProveMulti returns a [MultiProof] for the leaves at the given indices.
+// The indices may be given in any order and duplicates are ignored.
+func (t *BinaryTree) ProveMulti(leafIndices ...int) (*MultiProof, error) {
+	if len(leafIndices) == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	indices := slices.Clone(leafIndices)
+	slices.Sort(indices)
+	indices = slices.Compact(indices)
+	if indices[0] < 0 || indices[len(indices)-1] >= t.size {
+		return nil, ErrLeafIndexOutOfRange
+	}
+
+	proof := &MultiProof{
+		LeafIndices: indices,
+		TreeSize:    t.size,
+	}
+
+	var visit func(node *BinaryTree, start int, indices []int)
+	visit = func(node *BinaryTree, start int, indices []int) {
+		if len(indices) == 0 {
+			proof.Hashes = append(proof.Hashes, node.hash)
+			return
+		}
+		if node.IsLeaf() {
+			return
+		}
+
+		i, _ := slices.BinarySearch(indices, start+node.left.size)
+		visit(node.left, start, indices[:i])
+		visit(node.right, start+node.left.size, indices[i:])
+	}
+	visit(t, 0, indices)
+
+	return proof, nil
+}
+
+// VerifyMultiInclusion verifies that the given leaves are contained in the tree with
+// the given root hash. The leaves must be given in the same order as the leaf indices
+// of the proof. The hasher and options must be the same as those used to construct
+// the tree. A nil error is only returned if the proof is valid.
+func VerifyMultiInclusion[T io.Reader](hasher hash.Hash, root []byte, leafs []T, proof *MultiProof, opts ...Option) error {
+	if proof == nil || len(proof.LeafIndices) == 0 || len(proof.LeafIndices) != len(leafs) {
+		return ErrInvalidProof
+	}
+	for i, idx := range proof.LeafIndices {
+		if idx < 0 || idx >= proof.TreeSize || (i > 0 && idx <= proof.LeafIndices[i-1]) {
+			return ErrInvalidProof
+		}
+	}
+
+	o := applyOptions(opts)
+
+	hashes := proof.Hashes
+	next := 0
+
+	var compute func(start, n int, indices []int) ([]byte, error)
+	compute = func(start, n int, indices []int) ([]byte, error) {
+		if len(indices) == 0 {
+			if len(hashes) == 0 {
+				return nil, ErrInvalidProof
+			}
+			hash := hashes[0]
+			hashes = hashes[1:]
+			return hash, nil
+		}
+		if n == 1 {
+			leaf := leafs[next]
+			next += 1
+			return o.scheme.hashLeaf(hasher, leaf)
+		}
+
+		k := splitPoint(n)
+		i, _ := slices.BinarySearch(indices, start+k)
+		left, err := compute(start, k, indices[:i])
+		if err != nil {
+			return nil, err
+		}
+		right, err := compute(start+k, n-k, indices[i:])
+		if err != nil {
+			return nil, err
+		}
+		return o.scheme.hashChildren(hasher, left, right)
+	}
+
+	hash, err := compute(0, proof.TreeSize, proof.LeafIndices)
+	if err != nil {
+		return err
+	}
+	if len(hashes) != 0 {
+		return ErrInvalidProof
+	}
+
+	if !bytes.Equal(hash, root) {
+		return ErrRootMismatch
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func leafReaders(indices []int) []io.Reader {
+	leafs := make([]io.Reader, len(indices))
+	for i, idx := range indices {
+		leafs[i] = strings.NewReader(strconv.Itoa(idx))
+	}
+	return leafs
+}
+
+func TestBinaryTree_ProveMulti(t *testing.T) {
+	t.Run("will verify every combination of leaves", func(t *testing.T) {
+		for n := 1; n <= 9; n++ {
+			tree, err := NewBinaryTree(sha256.New(), leafValues(n), WithScheme(DomainSeparatedScheme))
+			require.Nil(t, err)
+
+			for mask := 1; mask < 1<<n; mask++ {
+				var indices []int
+				for i := range n {
+					if mask&(1<<i) != 0 {
+						indices = append(indices, i)
+					}
+				}
+
+				proof, err := tree.ProveMulti(indices...)
+				require.Nil(t, err)
+
+				err = VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices), proof, WithScheme(DomainSeparatedScheme))
+				require.Nil(t, err, "size %d, indices %v", n, indices)
+			}
+		}
+	})
+
+	t.Run("will deduplicate shared hashes", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(8))
+		require.Nil(t, err)
+
+		proof, err := tree.ProveMulti(0, 1, 2, 3)
+		require.Nil(t, err)
+		require.Equal(t, [][]byte{tree.Right().Hash()}, proof.Hashes)
+
+		proof, err = tree.ProveMulti(2, 0, 2)
+		require.Nil(t, err)
+		require.Equal(t, []int{0, 2}, proof.LeafIndices)
+		require.Len(t, proof.Hashes, 3)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(5))
+		require.Nil(t, err)
+
+		t.Run("if no leaf indices are given", func(t *testing.T) {
+			_, err := tree.ProveMulti()
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if a leaf index is out of range", func(t *testing.T) {
+			_, err := tree.ProveMulti(1, 5)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+
+			_, err = tree.ProveMulti(-1, 1)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+	})
+}
+
+func TestVerifyMultiInclusion(t *testing.T) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(13))
+	require.Nil(t, err)
+
+	indices := []int{1, 4, 5, 12}
+	prove := func(t *testing.T) *MultiProof {
+		proof, err := tree.ProveMulti(indices...)
+		require.Nil(t, err)
+		return proof
+	}
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is nil", func(t *testing.T) {
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices), nil)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if a leaf is different", func(t *testing.T) {
+			leafs := leafReaders(indices)
+			leafs[2] = strings.NewReader("changed")
+
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafs, prove(t))
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the number of leaves does not match", func(t *testing.T) {
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices[1:]), prove(t))
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the leaf indices are not ascending", func(t *testing.T) {
+			proof := prove(t)
+			proof.LeafIndices[0], proof.LeafIndices[1] = proof.LeafIndices[1], proof.LeafIndices[0]
+
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if a hash is missing", func(t *testing.T) {
+			proof := prove(t)
+			proof.Hashes = proof.Hashes[1:]
+
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if there are too many hashes", func(t *testing.T) {
+			proof := prove(t)
+			proof.Hashes = append(proof.Hashes, proof.Hashes[0])
+
+			err := VerifyMultiInclusion(sha256.New(), tree.Hash(), leafReaders(indices), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+	})
+}
+
+func BenchmarkMultiProof(b *testing.B) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(1<<16))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	r := rand.New(rand.NewPCG(1, 1))
+	indices := make([]int, 1000)
+	for i := range indices {
+		indices[i] = r.IntN(tree.size)
+	}
+
+	b.Run("individual", func(b *testing.B) {
+		var size int
+		for b.Loop() {
+			size = 0
+			for _, idx := range indices {
+				proof, err := tree.Prove(idx)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size += len(proof.Path) * sha256.Size
+			}
+		}
+		b.ReportMetric(float64(size), "proof-bytes")
+	})
+
+	b.Run("multi", func(b *testing.B) {
+		var size int
+		for b.Loop() {
+			proof, err := tree.ProveMulti(indices...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(proof.Hashes) * sha256.Size
+		}
+		b.ReportMetric(float64(size), "proof-bytes")
+	})
+}
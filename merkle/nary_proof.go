@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"hash"
+	"io"
+	"slices"
+)
+
+// NaryProofStep is a single level along the audit path of an [NaryTree].
+type NaryProofStep struct {
+	// Position is the index of the current node among the children of its parent.
+	Position int
+
+	// Siblings contains the hashes of every other child of the parent, in order.
+	// The current node is omitted, so it must be inserted at Position when
+	// computing the hash of the parent.
+	Siblings [][]byte
+}
+
+// NaryInclusionProof is an audit path which proves a leaf is contained
+// in an [NaryTree] with a specific root hash.
+type NaryInclusionProof struct {
+	// LeafIndex is the index of the proven leaf.
+	LeafIndex int
+
+	// TreeSize is the number of leaves in the tree the proof was generated from.
+	TreeSize int
+
+	// FanOut is the fan-out of the tree the proof was generated from.
+	FanOut int
+
+	// Path contains a step for every level, ordered from the leaf up to the root.
+	Path []NaryProofStep
+}
+
+// Prove returns an [NaryInclusionProof] for the leaf at the given index. Each step
+// of the proof contains up to [NaryTree.FanOut] - 1 sibling hashes.
+func (t *NaryTree) Prove(leafIndex int) (*NaryInclusionProof, error) {
+	if leafIndex < 0 || leafIndex >= t.size {
+		return nil, ErrLeafIndexOutOfRange
+	}
+
+	proof := &NaryInclusionProof{
+		LeafIndex: leafIndex,
+		TreeSize:  t.size,
+		FanOut:    t.fanOut,
+	}
+
+	node := t
+	idx := leafIndex
+	for !node.IsLeaf() {
+		step := NaryProofStep{
+			Siblings: make([][]byte, 0, len(node.children)-1),
+		}
+
+		var next *NaryTree
+		for i, child := range node.children {
+			if next == nil && idx < child.size {
+				step.Position = i
+				next = child
+				continue
+			}
+			if next == nil {
+				idx -= child.size
+			}
+			step.Siblings = append(step.Siblings, child.hash)
+		}
+
+		proof.Path = append(proof.Path, step)
+		node = next
+	}
+
+	// path was collected from the root down but is verified from the leaf up
+	slices.Reverse(proof.Path)
+	return proof, nil
+}
+
+// VerifyNaryInclusion verifies that the given leaf is contained in the tree with
+// the given root hash. The hasher and options must be the same as those used to
+// construct the tree. A nil error is only returned if the proof is valid.
+func VerifyNaryInclusion(hasher hash.Hash, root []byte, leaf io.Reader, proof *NaryInclusionProof, opts ...Option) error {
+	if proof == nil || proof.FanOut < 2 {
+		return ErrInvalidProof
+	}
+	if proof.LeafIndex < 0 || proof.LeafIndex >= proof.TreeSize {
+		return ErrInvalidProof
+	}
+
+	shape := naryAuditPathShape(proof.LeafIndex, proof.TreeSize, proof.FanOut)
+	if len(shape) != len(proof.Path) {
+		return ErrInvalidProof
+	}
+
+	o := applyOptions(opts)
+
+	hash, err := o.scheme.hashLeaf(hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range proof.Path {
+		if step.Position != shape[i].position || len(step.Siblings) != shape[i].groupSize-1 {
+			return ErrInvalidProof
+		}
+
+		children := slices.Insert(slices.Clone(step.Siblings), step.Position, hash)
+		hash, err = o.scheme.hashChildren(hasher, children...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(hash, root) {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+type naryProofShape struct {
+	position  int
+	groupSize int
+}
+
+// naryAuditPathShape returns the position of the current node and the number
+// of children of its parent for every level of the audit path, ordered from the
+// leaf up to the root. Levels where the current node is promoted, since it has no
+// siblings, are skipped.
+func naryAuditPathShape(leafIndex, treeSize, fanOut int) []naryProofShape {
+	var shape []naryProofShape
+	for treeSize > 1 {
+		start := leafIndex / fanOut * fanOut
+		groupSize := min(fanOut, treeSize-start)
+		if groupSize > 1 {
+			shape = append(shape, naryProofShape{
+				position:  leafIndex - start,
+				groupSize: groupSize,
+			})
+		}
+
+		leafIndex /= fanOut
+		treeSize = (treeSize + fanOut - 1) / fanOut
+	}
+	return shape
+}
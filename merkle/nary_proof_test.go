@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaryTree_Prove(t *testing.T) {
+	t.Run("will verify every leaf", func(t *testing.T) {
+		for _, fanOut := range []int{2, 3, 4, 16} {
+			for n := 1; n <= 40; n++ {
+				tree, err := NewNaryTree(sha256.New(), fanOut, leafValues(n), WithScheme(DomainSeparatedScheme))
+				require.Nil(t, err)
+
+				for i := range n {
+					proof, err := tree.Prove(i)
+					require.Nil(t, err)
+
+					leaf := strings.NewReader(strconv.Itoa(i))
+					err = VerifyNaryInclusion(sha256.New(), tree.Hash(), leaf, proof, WithScheme(DomainSeparatedScheme))
+					require.Nil(t, err, "fan-out %d, size %d, index %d", fanOut, n, i)
+				}
+			}
+		}
+	})
+
+	t.Run("will size each step to the fan-out", func(t *testing.T) {
+		tree, err := NewNaryTree(sha256.New(), 16, leafValues(256))
+		require.Nil(t, err)
+
+		proof, err := tree.Prove(100)
+		require.Nil(t, err)
+		require.Len(t, proof.Path, 2)
+		for _, step := range proof.Path {
+			require.Len(t, step.Siblings, 15)
+		}
+		require.Equal(t, 100%16, proof.Path[0].Position)
+		require.Equal(t, 100/16, proof.Path[1].Position)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			tree, err := NewNaryTree(sha256.New(), 4, leafValues(5))
+			require.Nil(t, err)
+
+			_, err = tree.Prove(-1)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+
+			_, err = tree.Prove(5)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+	})
+}
+
+func TestVerifyNaryInclusion(t *testing.T) {
+	tree, err := NewNaryTree(sha256.New(), 4, leafValues(11))
+	require.Nil(t, err)
+
+	prove := func(t *testing.T, leafIndex int) *NaryInclusionProof {
+		proof, err := tree.Prove(leafIndex)
+		require.Nil(t, err)
+		return proof
+	}
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is nil", func(t *testing.T) {
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("1"), nil)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the leaf is different", func(t *testing.T) {
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("2"), prove(t, 1))
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the fan-out is different", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.FanOut = 3
+
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the position is different", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.Path[0].Position = 2
+
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if a sibling is missing", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.Path[0].Siblings = proof.Path[0].Siblings[1:]
+
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			proof := prove(t, 1)
+			proof.LeafIndex = 11
+
+			err := VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+	})
+}
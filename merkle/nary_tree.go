@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+// NaryTree is a merkle tree where each node in the tree has up to a fixed
+// number of child nodes, also known as its fan-out. Wide trees are shallower
+// than a [BinaryTree] with the same number of leaves, which reduces the number
+// of hashes needed to reach a leaf at the cost of larger proofs per level.
+type NaryTree struct {
+	hash     []byte
+	children []*NaryTree
+
+	// size is the number of leaves contained in this tree.
+	size int
+
+	// fanOut is the maximum number of children of each node in this tree.
+	fanOut int
+
+	// scheme is the [Scheme] used to compute the hash of this tree.
+	scheme Scheme
+}
+
+// ErrInvalidFanOut is returned if an [NaryTree] is constructed with
+// a fan-out of less than 2.
+var ErrInvalidFanOut = errors.New("fan-out must be at least 2")
+
+// NewNaryTree will construct a full merkle [NaryTree] from the given leaf nodes, where
+// each interior node has up to fanOut children. Consecutive nodes on each level are
+// grouped under a common parent. If the last group on a level only contains a single
+// node, it is promoted to the next level instead of being hashed again, so a tree with
+// a fan-out of 2 has the same root hash as a [BinaryTree] constructed from the same
+// leaves and options.
+func NewNaryTree[T io.Reader](hasher hash.Hash, fanOut int, leafs []T, opts ...Option) (*NaryTree, error) {
+	if fanOut < 2 {
+		return nil, ErrInvalidFanOut
+	}
+	if len(leafs) == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	o := applyOptions(opts)
+
+	nodes := make([]*NaryTree, len(leafs))
+	for i, leaf := range leafs {
+		hash, err := o.scheme.hashLeaf(hasher, leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[i] = &NaryTree{
+			hash:   hash,
+			size:   1,
+			fanOut: fanOut,
+			scheme: o.scheme,
+		}
+	}
+
+	for len(nodes) > 1 {
+		newNodes := make([]*NaryTree, 0, (len(nodes)+fanOut-1)/fanOut)
+		for i := 0; i < len(nodes); i += fanOut {
+			group := nodes[i:min(i+fanOut, len(nodes))]
+			if len(group) == 1 {
+				newNodes = append(newNodes, group[0])
+				continue
+			}
+
+			node, err := newNaryNode(hasher, o.scheme, fanOut, group)
+			if err != nil {
+				return nil, err
+			}
+			newNodes = append(newNodes, node)
+		}
+		nodes = newNodes
+	}
+	return nodes[0], nil
+}
+
+func newNaryNode(hasher hash.Hash, scheme Scheme, fanOut int, children []*NaryTree) (*NaryTree, error) {
+	hashes := make([][]byte, len(children))
+	size := 0
+	for i, child := range children {
+		hashes[i] = child.hash
+		size += child.size
+	}
+
+	hash, err := scheme.hashChildren(hasher, hashes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NaryTree{
+		hash:     hash,
+		children: children,
+		size:     size,
+		fanOut:   fanOut,
+		scheme:   scheme,
+	}, nil
+}
+
+// Hash returns the raw hash value for this tree.
+func (t *NaryTree) Hash() []byte {
+	return t.hash
+}
+
+// String returns a hex encoded representation of the hash.
+func (t *NaryTree) String() string {
+	return hex.EncodeToString(t.Hash())
+}
+
+// FanOut returns the maximum number of children of each node in this tree.
+func (t *NaryTree) FanOut() int {
+	return t.fanOut
+}
+
+// Children returns the child trees in order. Note, this will be empty if [IsLeaf]
+// returns true and may contain fewer than [FanOut] trees for the last node on a level.
+func (t *NaryTree) Children() []*NaryTree {
+	return t.children
+}
+
+// IsLeaf reports whether this tree represents a leaf value.
+func (t *NaryTree) IsLeaf() bool {
+	return len(t.children) == 0
+}
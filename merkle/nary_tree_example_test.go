@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func ExampleNewNaryTree() {
+	leafs := make([]io.Reader, 256)
+	for i := range leafs {
+		leafs[i] = strings.NewReader(strconv.Itoa(i))
+	}
+
+	tree, err := NewNaryTree(sha256.New(), 16, leafs, WithScheme(DomainSeparatedScheme))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	proof, err := tree.Prove(42)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(len(proof.Path), len(proof.Path[0].Siblings))
+
+	err = VerifyNaryInclusion(sha256.New(), tree.Hash(), strings.NewReader("42"), proof, WithScheme(DomainSeparatedScheme))
+	fmt.Println(err)
+
+	// Output: 2 15
+	// <nil>
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNaryTree(t *testing.T) {
+	t.Run("will match a BinaryTree if the fan-out is 2", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			for n := 1; n <= 33; n++ {
+				binary, err := NewBinaryTree(sha256.New(), leafValues(n), WithScheme(scheme))
+				require.Nil(t, err)
+
+				nary, err := NewNaryTree(sha256.New(), 2, leafValues(n), WithScheme(scheme))
+				require.Nil(t, err)
+				require.Equal(t, binary.Hash(), nary.Hash(), n)
+			}
+		}
+	})
+
+	t.Run("will hash up to fan-out children per node", func(t *testing.T) {
+		tree, err := NewNaryTree(sha256.New(), 3, leafValues(7))
+		require.Nil(t, err)
+		require.Equal(t, 3, tree.FanOut())
+		require.False(t, tree.IsLeaf())
+
+		// 7 leaves are grouped as [0 1 2] [3 4 5] [6], where the last leaf is
+		// promoted, and those 3 nodes are the children of the root
+		require.Len(t, tree.Children(), 3)
+		require.Len(t, tree.Children()[0].Children(), 3)
+		require.Len(t, tree.Children()[1].Children(), 3)
+		require.True(t, tree.Children()[2].IsLeaf())
+
+		hasher := sha256.New()
+		hasher.Write(tree.Children()[0].Hash())
+		hasher.Write(tree.Children()[1].Hash())
+		hasher.Write(tree.Children()[2].Hash())
+		require.Equal(t, hasher.Sum(nil), tree.Hash())
+	})
+
+	t.Run("will produce a shallower tree for a larger fan-out", func(t *testing.T) {
+		depth := func(tree *NaryTree) int {
+			d := 0
+			for !tree.IsLeaf() {
+				tree = tree.Children()[0]
+				d++
+			}
+			return d
+		}
+
+		narrow, err := NewNaryTree(sha256.New(), 2, leafValues(256))
+		require.Nil(t, err)
+		require.Equal(t, 8, depth(narrow))
+
+		wide, err := NewNaryTree(sha256.New(), 16, leafValues(256))
+		require.Nil(t, err)
+		require.Equal(t, 2, depth(wide))
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the fan-out is less than 2", func(t *testing.T) {
+			tree, err := NewNaryTree(sha256.New(), 1, leafValues(4))
+			require.ErrorIs(t, err, ErrInvalidFanOut)
+			require.Nil(t, tree)
+		})
+
+		t.Run("if no leaves are given", func(t *testing.T) {
+			tree, err := NewNaryTree(sha256.New(), 4, []io.Reader{})
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+			require.Nil(t, tree)
+		})
+
+		t.Run("if a leaf fails to be read", func(t *testing.T) {
+			leafs := []io.Reader{
+				strings.NewReader("a"),
+				readFunc(func(b []byte) (int, error) {
+					return 0, errReadFailed
+				}),
+			}
+
+			tree, err := NewNaryTree(sha256.New(), 4, leafs)
+			require.ErrorIs(t, err, errReadFailed)
+			require.Nil(t, tree)
+		})
+	})
+}
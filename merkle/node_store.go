@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+)
+
+// ErrNodeNotFound is returned by a [NodeStore] if no node is stored
+// at the requested position.
+var ErrNodeNotFound = errors.New("node not found")
+
+// NodeStore is an append-only store of node hashes, which are addressed by
+// their position in the order they were appended. Once appended, the hash at
+// a position must never change.
+type NodeStore interface {
+	// Node returns the hash stored at the given position. If no hash has been
+	// stored at the position yet, [ErrNodeNotFound] must be returned.
+	Node(pos uint64) ([]byte, error)
+
+	// Append stores the given hashes at the next positions, in order.
+	Append(hashes ...[]byte) error
+
+	// Size returns the number of hashes which have been appended.
+	Size() (uint64, error)
+}
+
+// MemoryNodeStore is a [NodeStore] which keeps every node in memory.
+// It is safe for concurrent use.
+type MemoryNodeStore struct {
+	mu    sync.RWMutex
+	nodes [][]byte
+}
+
+// NewMemoryNodeStore returns an empty [MemoryNodeStore].
+func NewMemoryNodeStore() *MemoryNodeStore {
+	return &MemoryNodeStore{}
+}
+
+// Node implements the [NodeStore] interface.
+func (s *MemoryNodeStore) Node(pos uint64) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if pos >= uint64(len(s.nodes)) {
+		return nil, ErrNodeNotFound
+	}
+	return s.nodes[pos], nil
+}
+
+// Append implements the [NodeStore] interface.
+func (s *MemoryNodeStore) Append(hashes ...[]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, hash := range hashes {
+		s.nodes = append(s.nodes, bytes.Clone(hash))
+	}
+	return nil
+}
+
+// Size implements the [NodeStore] interface.
+func (s *MemoryNodeStore) Size() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return uint64(len(s.nodes)), nil
+}
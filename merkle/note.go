@@ -0,0 +1,307 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// noteAlgEd25519 identifies Ed25519 keys in the signed note format.
+const noteAlgEd25519 = 1
+
+// maxNoteSignatures is the maximum number of signatures accepted on a single note.
+const maxNoteSignatures = 100
+
+var (
+	// ErrMalformedNote is returned when parsing data which is not a valid signed note.
+	ErrMalformedNote = errors.New("malformed note")
+
+	// ErrMalformedNoteKey is returned when parsing an invalid signer or verifier key.
+	ErrMalformedNoteKey = errors.New("malformed note key")
+
+	// ErrInvalidSignature is returned if a note carries a signature from a known
+	// verifier which does not verify.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrInsufficientSignatures is returned if a note is not signed by enough
+	// of the known verifiers.
+	ErrInsufficientSignatures = errors.New("insufficient signatures")
+)
+
+// NoteSigner signs notes with an Ed25519 private key, using the signed note
+// format of the Go checksum database.
+type NoteSigner struct {
+	name string
+	hash uint32
+	key  ed25519.PrivateKey
+}
+
+// NewNoteSigner returns a [NoteSigner] which signs notes under the given name.
+func NewNoteSigner(name string, key ed25519.PrivateKey) (*NoteSigner, error) {
+	if !isValidNoteName(name) || len(key) != ed25519.PrivateKeySize {
+		return nil, ErrMalformedNoteKey
+	}
+
+	return &NoteSigner{
+		name: name,
+		hash: noteKeyHash(name, key.Public().(ed25519.PublicKey)),
+		key:  key,
+	}, nil
+}
+
+// ParseNoteSigner parses a signer key of the form "PRIVATE+KEY+<name>+<hash>+<key>",
+// as used by golang.org/x/mod/sumdb/note.
+func ParseNoteSigner(skey string) (*NoteSigner, error) {
+	rest, ok := strings.CutPrefix(skey, "PRIVATE+KEY+")
+	if !ok {
+		return nil, ErrMalformedNoteKey
+	}
+
+	name, hash, seed, ok := parseNoteKey(rest)
+	if !ok || len(seed) != ed25519.SeedSize {
+		return nil, ErrMalformedNoteKey
+	}
+
+	s, err := NewNoteSigner(name, ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return nil, err
+	}
+	if s.hash != hash {
+		return nil, ErrMalformedNoteKey
+	}
+	return s, nil
+}
+
+// Name returns the name of the signer.
+func (s *NoteSigner) Name() string {
+	return s.name
+}
+
+// Verifier returns the [NoteVerifier] for the signatures of this signer.
+func (s *NoteSigner) Verifier() *NoteVerifier {
+	return &NoteVerifier{
+		name: s.name,
+		hash: s.hash,
+		key:  s.key.Public().(ed25519.PublicKey),
+	}
+}
+
+// sign returns the signature line for the given note text.
+func (s *NoteSigner) sign(text []byte) string {
+	sig := binary.BigEndian.AppendUint32(nil, s.hash)
+	sig = append(sig, ed25519.Sign(s.key, text)...)
+	return "— " + s.name + " " + base64.StdEncoding.EncodeToString(sig) + "\n"
+}
+
+// NoteVerifier verifies the signatures of a single [NoteSigner].
+type NoteVerifier struct {
+	name string
+	hash uint32
+	key  ed25519.PublicKey
+}
+
+// NewNoteVerifier returns a [NoteVerifier] for the signatures
+// made under the given name with the given public key.
+func NewNoteVerifier(name string, key ed25519.PublicKey) (*NoteVerifier, error) {
+	if !isValidNoteName(name) || len(key) != ed25519.PublicKeySize {
+		return nil, ErrMalformedNoteKey
+	}
+
+	return &NoteVerifier{
+		name: name,
+		hash: noteKeyHash(name, key),
+		key:  key,
+	}, nil
+}
+
+// ParseNoteVerifier parses a verifier key of the form "<name>+<hash>+<key>",
+// as returned by [NoteVerifier.String].
+func ParseNoteVerifier(vkey string) (*NoteVerifier, error) {
+	name, hash, key, ok := parseNoteKey(vkey)
+	if !ok || len(key) != ed25519.PublicKeySize {
+		return nil, ErrMalformedNoteKey
+	}
+
+	v, err := NewNoteVerifier(name, key)
+	if err != nil {
+		return nil, err
+	}
+	if v.hash != hash {
+		return nil, ErrMalformedNoteKey
+	}
+	return v, nil
+}
+
+// Name returns the name of the signer whose signatures are verified.
+func (v *NoteVerifier) Name() string {
+	return v.name
+}
+
+// String returns the verifier key, which can be parsed by [ParseNoteVerifier].
+func (v *NoteVerifier) String() string {
+	key := append([]byte{noteAlgEd25519}, v.key...)
+	return fmt.Sprintf("%s+%08x+%s", v.name, v.hash, base64.StdEncoding.EncodeToString(key))
+}
+
+// noteKeyHash returns the hash which identifies the key of a signer
+// in the signatures it produces.
+func noteKeyHash(name string, key ed25519.PublicKey) uint32 {
+	h := sha256.New()
+	h.Write([]byte(name))
+	h.Write([]byte("\n"))
+	h.Write([]byte{noteAlgEd25519})
+	h.Write(key)
+	return binary.BigEndian.Uint32(h.Sum(nil))
+}
+
+// parseNoteKey parses "<name>+<hash>+<key>" where key is the base64 encoding
+// of the algorithm byte followed by the raw key.
+func parseNoteKey(s string) (name string, hash uint32, key []byte, ok bool) {
+	name, rest, ok := strings.Cut(s, "+")
+	if !ok || !isValidNoteName(name) {
+		return "", 0, nil, false
+	}
+
+	hashHex, keyBase64, ok := strings.Cut(rest, "+")
+	if !ok || len(hashHex) != 8 {
+		return "", 0, nil, false
+	}
+
+	h, err := strconv.ParseUint(hashHex, 16, 32)
+	if err != nil {
+		return "", 0, nil, false
+	}
+
+	key, err = base64.StdEncoding.DecodeString(keyBase64)
+	if err != nil || len(key) == 0 || key[0] != noteAlgEd25519 {
+		return "", 0, nil, false
+	}
+	return name, uint32(h), key[1:], true
+}
+
+func isValidNoteName(name string) bool {
+	return name != "" && utf8.ValidString(name) && strings.IndexFunc(name, unicode.IsSpace) < 0 && !strings.Contains(name, "+")
+}
+
+// noteSignature is a single signature line of a signed note.
+type noteSignature struct {
+	name string
+	hash uint32
+	sig  []byte
+}
+
+// signNote returns the given text signed by every given signer.
+func signNote(text []byte, signers ...*NoteSigner) ([]byte, error) {
+	if !isValidNoteText(text) {
+		return nil, ErrMalformedNote
+	}
+
+	var buf bytes.Buffer
+	buf.Write(text)
+	buf.WriteByte('\n')
+	for _, s := range signers {
+		buf.WriteString(s.sign(text))
+	}
+	return buf.Bytes(), nil
+}
+
+// splitNote splits a signed note into its text and signatures.
+func splitNote(msg []byte) ([]byte, []noteSignature, error) {
+	i := bytes.LastIndex(msg, []byte("\n\n"))
+	if i < 0 {
+		return nil, nil, ErrMalformedNote
+	}
+
+	text, sigs := msg[:i+1], msg[i+2:]
+	if !isValidNoteText(text) || len(sigs) == 0 || sigs[len(sigs)-1] != '\n' {
+		return nil, nil, ErrMalformedNote
+	}
+
+	var signatures []noteSignature
+	for _, line := range strings.Split(string(sigs[:len(sigs)-1]), "\n") {
+		rest, ok := strings.CutPrefix(line, "— ")
+		if !ok {
+			return nil, nil, ErrMalformedNote
+		}
+		name, b64, ok := strings.Cut(rest, " ")
+		if !ok || !isValidNoteName(name) {
+			return nil, nil, ErrMalformedNote
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil || len(sig) < 5 {
+			return nil, nil, ErrMalformedNote
+		}
+
+		signatures = append(signatures, noteSignature{
+			name: name,
+			hash: binary.BigEndian.Uint32(sig),
+			sig:  sig[4:],
+		})
+		if len(signatures) > maxNoteSignatures {
+			return nil, nil, ErrMalformedNote
+		}
+	}
+	return text, signatures, nil
+}
+
+// openNote verifies the signatures of a signed note and returns its text, if it
+// is validly signed by at least threshold of the given verifiers. Signatures from
+// unknown signers are ignored.
+func openNote(msg []byte, threshold int, verifiers ...*NoteVerifier) ([]byte, error) {
+	text, signatures, err := splitNote(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// signatures are counted per key, so neither a verifier given twice
+	// nor a signature repeated in the note counts towards the threshold twice
+	type keyID struct {
+		name string
+		hash uint32
+	}
+	verified := make(map[keyID]bool, len(verifiers))
+	for _, sig := range signatures {
+		for _, v := range verifiers {
+			if v.name != sig.name || v.hash != sig.hash {
+				continue
+			}
+			if !ed25519.Verify(v.key, text, sig.sig) {
+				return nil, ErrInvalidSignature
+			}
+			verified[keyID{name: v.name, hash: v.hash}] = true
+		}
+	}
+
+	if len(verified) < max(threshold, 1) {
+		return nil, ErrInsufficientSignatures
+	}
+	return text, nil
+}
+
+// isValidNoteText reports whether the text of a note is valid UTF-8 without
+// any control characters, except newlines, and ends with a newline.
+func isValidNoteText(text []byte) bool {
+	if len(text) == 0 || text[len(text)-1] != '\n' || !utf8.Valid(text) {
+		return false
+	}
+	for _, r := range string(text) {
+		if r != '\n' && unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteSigner(t *testing.T) {
+	t.Run("will produce the same signatures as golang.org/x/mod/sumdb/note", func(t *testing.T) {
+		signer, err := ParseNoteSigner("PRIVATE+KEY+PeterNeumann+c74f20a3+AYEKFALVFGyNhPJEMzD1QIDr+Y7hfZx09iUvxdXHKDFz")
+		require.Nil(t, err)
+		require.Equal(t, "PeterNeumann+c74f20a3+ARpc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW", signer.Verifier().String())
+
+		text := "If you think cryptography is the answer to your problem,\nthen you don't know what your problem is.\n"
+		note, err := signNote([]byte(text), signer)
+		require.Nil(t, err)
+		require.Equal(t, text+"\n— PeterNeumann x08go/ZJkuBS9UG/SffcvIAQxVBtiFupLLr8pAcElZInNIuGUgYN1FFYC2pZSNXgKvqfqdngotpRZb6KE6RyyBwJnAM=\n", string(note))
+	})
+}
+
+func TestParseNoteVerifier(t *testing.T) {
+	t.Run("will round trip", func(t *testing.T) {
+		vkey := "PeterNeumann+c74f20a3+ARpc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW"
+
+		v, err := ParseNoteVerifier(vkey)
+		require.Nil(t, err)
+		require.Equal(t, "PeterNeumann", v.Name())
+		require.Equal(t, vkey, v.String())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		testCases := []struct {
+			Name string
+			Key  string
+		}{
+			{Name: "if the key is empty", Key: ""},
+			{Name: "if the hash is missing", Key: "PeterNeumann+ARpc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW"},
+			{Name: "if the hash does not match", Key: "PeterNeumann+c74f20a4+ARpc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW"},
+			{Name: "if the name does not match", Key: "PeterNeuman+c74f20a3+ARpc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW"},
+			{Name: "if the algorithm is unknown", Key: "PeterNeumann+c74f20a3+Apc2QcUPDhMQegwxbzhKqiBfsVkmqq/LDE4izWy10TW"},
+			{Name: "if the key is not base64", Key: "PeterNeumann+c74f20a3+!!!!"},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				_, err := ParseNoteVerifier(testCase.Key)
+				require.ErrorIs(t, err, ErrMalformedNoteKey)
+			})
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+// Option configures the [Scheme] used by the tree constructors and proof
+// verifiers in this package, see [WithScheme]. Anything which can be configured
+// any further accepts its own option type instead, which every Option also
+// implements if a scheme applies, so an option without effect never compiles.
+type Option func(*options)
+
+type options struct {
+	scheme Scheme
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (opt Option) applyBinaryTree(o *binaryTreeOptions) {
+	opt(&o.options)
+}
+
+func (opt Option) applyParallel(o *parallelOptions) {
+	opt(&o.options)
+}
+
+func (opt Option) applyHashFS(o *hashFSOptions) {
+	opt(&o.options)
+}
+
+func (opt Option) applyAuditLog(o *auditLogOptions) {
+	opt(&o.options)
+}
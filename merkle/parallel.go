@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"hash"
+	"io"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/z5labs/sdk-go/concurrent"
+)
+
+// ParallelOption configures [NewBinaryTreeParallel]. Every [Option] and every
+// [BinaryTreeOption] is also a ParallelOption.
+type ParallelOption interface {
+	applyParallel(*parallelOptions)
+}
+
+type parallelOptions struct {
+	binaryTreeOptions
+	maxWorkers int
+}
+
+type maxWorkersOption int
+
+func (n maxWorkersOption) applyParallel(o *parallelOptions) {
+	o.maxWorkers = int(n)
+}
+
+// WithMaxWorkers limits the number of goroutines used by [NewBinaryTreeParallel]
+// to hash nodes. It defaults to [runtime.GOMAXPROCS].
+func WithMaxWorkers(n int) ParallelOption {
+	return maxWorkersOption(n)
+}
+
+// NewBinaryTreeParallel will construct a full merkle [BinaryTree] from the given leaf
+// nodes by hashing the leaves, and then each level of the tree, in parallel. Every
+// goroutine uses its own hasher, as returned by newHasher, so the resulting tree is
+// identical to one constructed by [NewBinaryTree] with the same hash function. Like
+// [NewBinaryTreeContext], the construction is aborted as soon as the given context
+// is cancelled.
+func NewBinaryTreeParallel[T io.Reader](ctx context.Context, newHasher func() hash.Hash, leafs []T, opts ...ParallelOption) (*BinaryTree, error) {
+	if len(leafs) == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	var o parallelOptions
+	for _, opt := range opts {
+		opt.applyParallel(&o)
+	}
+	tracker := newProgressTracker(o.progress, len(leafs))
+
+	maxWorkers := o.maxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.GOMAXPROCS(0)
+	}
+	hashers := make([]hash.Hash, min(maxWorkers, len(leafs)))
+	for i := range hashers {
+		hashers[i] = newHasher()
+	}
+
+	nodes := make([]*BinaryTree, len(leafs))
+	err := forEachParallel(ctx, hashers, len(leafs), func(ctx context.Context, hasher hash.Hash, i int) error {
+		hash, err := o.scheme.hashLeaf(hasher, leafReader{ctx: ctx, r: leafs[i], tracker: tracker})
+		if err != nil {
+			return err
+		}
+		tracker.leafHashed()
+
+		nodes[i] = &BinaryTree{
+			hash:   hash,
+			size:   1,
+			scheme: o.scheme,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for level := 1; len(nodes) > 1; level++ {
+		tracker.level(level)
+
+		newNodes := make([]*BinaryTree, (len(nodes)+1)/2)
+		if len(nodes)%2 != 0 {
+			newNodes[len(newNodes)-1] = nodes[len(nodes)-1]
+		}
+
+		err := forEachParallel(ctx, hashers, len(nodes)/2, func(_ context.Context, hasher hash.Hash, i int) error {
+			left := nodes[2*i]
+			right := nodes[2*i+1]
+
+			hash, err := o.scheme.hashChildren(hasher, left.hash, right.hash)
+			if err != nil {
+				return err
+			}
+
+			newNodes[i] = &BinaryTree{
+				hash:   hash,
+				left:   left,
+				right:  right,
+				size:   left.size + right.size,
+				scheme: o.scheme,
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = newNodes
+	}
+
+	return nodes[0], nil
+}
+
+// forEachParallel calls f for every index in [0, n) using at most one
+// goroutine per hasher. Each goroutine exclusively owns its hasher.
+func forEachParallel(ctx context.Context, hashers []hash.Hash, n int, f func(context.Context, hash.Hash, int) error) error {
+	var next atomic.Int64
+	var lg concurrent.LazyGroup
+	for _, hasher := range hashers[:min(len(hashers), n)] {
+		lg.Go(func(ctx context.Context) error {
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return nil
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				err := f(ctx, hasher, i)
+				if err != nil {
+					return err
+				}
+			}
+		})
+	}
+	return lg.Wait(ctx)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBinaryTreeParallel(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if no leaves are provided", func(t *testing.T) {
+			tree, err := NewBinaryTreeParallel[io.Reader](t.Context(), sha256.New, nil)
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+			require.Nil(t, tree)
+		})
+
+		t.Run("if a leaf fails to be read", func(t *testing.T) {
+			leafs := []io.Reader{
+				bytes.NewReader([]byte("a")),
+				readFunc(func(b []byte) (int, error) {
+					return 0, errReadFailed
+				}),
+				bytes.NewReader([]byte("c")),
+			}
+
+			tree, err := NewBinaryTreeParallel(t.Context(), sha256.New, leafs)
+			require.ErrorIs(t, err, errReadFailed)
+			require.Nil(t, tree)
+		})
+
+		t.Run("if the context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			cancel()
+
+			tree, err := NewBinaryTreeParallel(ctx, sha256.New, leafValues(10))
+			require.ErrorIs(t, err, context.Canceled)
+			require.Nil(t, tree)
+		})
+	})
+
+	t.Run("will be equal to a sequentially constructed tree", func(t *testing.T) {
+		for _, maxWorkers := range []int{0, 1, 3, 16} {
+			for _, numOfLeafs := range []int{1, 2, 3, 7, 8, 33, 100} {
+				t.Run(strconv.Itoa(numOfLeafs)+" leaves with "+strconv.Itoa(maxWorkers)+" max workers", func(t *testing.T) {
+					expected, err := NewBinaryTree(sha256.New(), leafValues(numOfLeafs), WithScheme(DomainSeparatedScheme))
+					require.Nil(t, err)
+
+					tree, err := NewBinaryTreeParallel(
+						t.Context(),
+						sha256.New,
+						leafValues(numOfLeafs),
+						WithScheme(DomainSeparatedScheme),
+						WithMaxWorkers(maxWorkers),
+					)
+					require.Nil(t, err)
+					require.Equal(t, expected, tree)
+				})
+			}
+		}
+	})
+}
+
+func BenchmarkNewBinaryTree(b *testing.B) {
+	leafs := make([][]byte, 256)
+	for i := range leafs {
+		leafs[i] = bytes.Repeat([]byte{byte(i)}, 64*1024)
+	}
+
+	readers := func() []*bytes.Reader {
+		rs := make([]*bytes.Reader, len(leafs))
+		for i, leaf := range leafs {
+			rs[i] = bytes.NewReader(leaf)
+		}
+		return rs
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			_, err := NewBinaryTree(sha256.New(), readers())
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for b.Loop() {
+			_, err := NewBinaryTreeParallel(b.Context(), sha256.New, readers())
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
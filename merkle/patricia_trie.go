@@ -0,0 +1,464 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"hash"
+)
+
+// PatriciaTrie is a Merkle Patricia trie as used by Ethereum for its state,
+// transaction and receipt tries. Nodes are RLP encoded and referenced by their
+// hash, unless their encoding is shorter than 32 bytes, in which case they are
+// embedded directly into their parent.
+//
+// To produce root hashes which are compatible with Ethereum, the trie must be
+// given a factory for the legacy Keccak-256 hash function, which is not the same
+// as the standardized SHA3-256. A PatriciaTrie is not safe for concurrent use.
+type PatriciaTrie struct {
+	hasher hash.Hash
+	root   patriciaNode
+}
+
+// patriciaNode is one of *patriciaLeaf, *patriciaExtension, *patriciaBranch
+// or nil for the empty trie.
+type patriciaNode any
+
+type patriciaLeaf struct {
+	path  []byte
+	value []byte
+}
+
+type patriciaExtension struct {
+	path  []byte
+	child patriciaNode
+}
+
+type patriciaBranch struct {
+	children [16]patriciaNode
+	value    []byte
+}
+
+// NewPatriciaTrie returns an empty [PatriciaTrie] which hashes its nodes
+// using a hasher returned by the given factory.
+func NewPatriciaTrie(newHasher func() hash.Hash) *PatriciaTrie {
+	return &PatriciaTrie{
+		hasher: newHasher(),
+	}
+}
+
+// Root returns the root hash of the trie.
+func (t *PatriciaTrie) Root() []byte {
+	return patriciaHash(t.hasher, t.encode(t.root))
+}
+
+// Get returns the value for the given key and true, if the
+// key is contained in the trie, otherwise it returns false.
+func (t *PatriciaTrie) Get(key []byte) ([]byte, bool) {
+	path := keyToNibbles(key)
+
+	node := t.root
+	for {
+		switch n := node.(type) {
+		case *patriciaLeaf:
+			if !bytes.Equal(n.path, path) {
+				return nil, false
+			}
+			return n.value, true
+		case *patriciaExtension:
+			if !bytes.HasPrefix(path, n.path) {
+				return nil, false
+			}
+			path = path[len(n.path):]
+			node = n.child
+		case *patriciaBranch:
+			if len(path) == 0 {
+				return n.value, n.value != nil
+			}
+			node = n.children[path[0]]
+			path = path[1:]
+		default:
+			return nil, false
+		}
+	}
+}
+
+// Set maps the given key to the given value, replacing any previous value.
+// Like Ethereum, setting an empty value is the same as deleting the key.
+func (t *PatriciaTrie) Set(key, value []byte) {
+	if len(value) == 0 {
+		t.Delete(key)
+		return
+	}
+	t.root = patriciaInsert(t.root, keyToNibbles(key), bytes.Clone(value))
+}
+
+// Delete removes the given key from the trie, if it is contained in it.
+func (t *PatriciaTrie) Delete(key []byte) {
+	t.root = patriciaDelete(t.root, keyToNibbles(key))
+}
+
+// Prove returns the RLP encoding of every node, which is referenced by its hash,
+// on the path from the root to the given key. This is the same format as returned
+// by Ethereum's eth_getProof and it can be checked with [VerifyPatriciaProof]
+// regardless of whether the key is contained in the trie or not.
+func (t *PatriciaTrie) Prove(key []byte) [][]byte {
+	path := keyToNibbles(key)
+
+	var proof [][]byte
+	node := t.root
+	for i := 0; ; i++ {
+		enc := t.encode(node)
+		if i == 0 || len(enc) >= t.hasher.Size() {
+			proof = append(proof, enc)
+		}
+
+		switch n := node.(type) {
+		case *patriciaExtension:
+			if !bytes.HasPrefix(path, n.path) {
+				return proof
+			}
+			path = path[len(n.path):]
+			node = n.child
+		case *patriciaBranch:
+			if len(path) == 0 {
+				return proof
+			}
+			node = n.children[path[0]]
+			path = path[1:]
+			if node == nil {
+				return proof
+			}
+		default:
+			return proof
+		}
+	}
+}
+
+// VerifyPatriciaProof verifies the given proof, as returned by [PatriciaTrie.Prove],
+// against the given root hash. It returns the value the key maps to, or nil if the
+// proof shows that the key is not contained in the trie. An error is returned if
+// the proof is invalid.
+func VerifyPatriciaProof(newHasher func() hash.Hash, root, key []byte, proof [][]byte) ([]byte, error) {
+	hasher := newHasher()
+
+	nodes := make(map[string][]byte, len(proof))
+	for _, enc := range proof {
+		nodes[string(patriciaHash(hasher, enc))] = enc
+	}
+
+	path := keyToNibbles(key)
+	enc, ok := nodes[string(root)]
+	if !ok {
+		return nil, ErrInvalidProof
+	}
+	item, rest, err := rlpSplit(enc)
+	if err != nil || len(rest) != 0 {
+		return nil, ErrInvalidProof
+	}
+
+	for {
+		if !item.list {
+			// only the root of an empty trie is encoded as an empty string
+			if len(item.raw) == 1 && item.raw[0] == 0x80 {
+				return nil, nil
+			}
+			return nil, ErrInvalidProof
+		}
+		items, err := rlpListItems(item.payload)
+		if err != nil {
+			return nil, ErrInvalidProof
+		}
+
+		var ref rlpItem
+		switch len(items) {
+		case 2:
+			if items[0].list {
+				return nil, ErrInvalidProof
+			}
+			nodePath, leaf, ok := hexPrefixDecode(items[0].payload)
+			if !ok {
+				return nil, ErrInvalidProof
+			}
+			if leaf {
+				if items[1].list {
+					return nil, ErrInvalidProof
+				}
+				if !bytes.Equal(nodePath, path) {
+					return nil, nil
+				}
+				return items[1].payload, nil
+			}
+
+			// an extension must always point to a child node
+			if !items[1].list && len(items[1].payload) == 0 {
+				return nil, ErrInvalidProof
+			}
+			if !bytes.HasPrefix(path, nodePath) {
+				return nil, nil
+			}
+			path = path[len(nodePath):]
+			ref = items[1]
+		case 17:
+			if items[16].list {
+				return nil, ErrInvalidProof
+			}
+			if len(path) == 0 {
+				if len(items[16].payload) == 0 {
+					return nil, nil
+				}
+				return items[16].payload, nil
+			}
+			ref = items[path[0]]
+			path = path[1:]
+		default:
+			return nil, ErrInvalidProof
+		}
+
+		switch {
+		case ref.list:
+			// the child is small enough to be embedded into its parent
+			item = ref
+		case len(ref.payload) == 0:
+			return nil, nil
+		default:
+			enc, ok := nodes[string(ref.payload)]
+			if !ok {
+				return nil, ErrInvalidProof
+			}
+			item, rest, err = rlpSplit(enc)
+			if err != nil || len(rest) != 0 {
+				return nil, ErrInvalidProof
+			}
+		}
+	}
+}
+
+func patriciaInsert(node patriciaNode, path, value []byte) patriciaNode {
+	switch n := node.(type) {
+	case *patriciaLeaf:
+		if bytes.Equal(n.path, path) {
+			return &patriciaLeaf{path: n.path, value: value}
+		}
+
+		common := commonPrefixLen(n.path, path)
+		branch := &patriciaBranch{}
+		branch.put(n.path[common:], n.value)
+		branch.put(path[common:], value)
+		return withExtension(path[:common], branch)
+	case *patriciaExtension:
+		common := commonPrefixLen(n.path, path)
+		if common == len(n.path) {
+			return &patriciaExtension{
+				path:  n.path,
+				child: patriciaInsert(n.child, path[common:], value),
+			}
+		}
+
+		branch := &patriciaBranch{}
+		branch.children[n.path[common]] = withExtension(n.path[common+1:], n.child)
+		branch.put(path[common:], value)
+		return withExtension(path[:common], branch)
+	case *patriciaBranch:
+		branch := *n
+		if len(path) == 0 {
+			branch.value = value
+			return &branch
+		}
+		branch.children[path[0]] = patriciaInsert(n.children[path[0]], path[1:], value)
+		return &branch
+	default:
+		return &patriciaLeaf{path: path, value: value}
+	}
+}
+
+// put stores the value at the given path relative to this branch.
+func (b *patriciaBranch) put(path, value []byte) {
+	if len(path) == 0 {
+		b.value = value
+		return
+	}
+	b.children[path[0]] = &patriciaLeaf{path: path[1:], value: value}
+}
+
+func withExtension(path []byte, child patriciaNode) patriciaNode {
+	if len(path) == 0 {
+		return child
+	}
+	return &patriciaExtension{path: path, child: child}
+}
+
+func patriciaDelete(node patriciaNode, path []byte) patriciaNode {
+	switch n := node.(type) {
+	case *patriciaLeaf:
+		if bytes.Equal(n.path, path) {
+			return nil
+		}
+		return n
+	case *patriciaExtension:
+		if !bytes.HasPrefix(path, n.path) {
+			return n
+		}
+		return joinPath(n.path, patriciaDelete(n.child, path[len(n.path):]))
+	case *patriciaBranch:
+		branch := *n
+		if len(path) == 0 {
+			branch.value = nil
+		} else {
+			branch.children[path[0]] = patriciaDelete(n.children[path[0]], path[1:])
+		}
+		return branch.collapse()
+	default:
+		return nil
+	}
+}
+
+// collapse replaces a branch which no longer has at least two
+// entries with an equivalent leaf or extension.
+func (b *patriciaBranch) collapse() patriciaNode {
+	only := -1
+	for i, child := range b.children {
+		if child == nil {
+			continue
+		}
+		if only >= 0 || b.value != nil {
+			return b
+		}
+		only = i
+	}
+
+	switch {
+	case only >= 0:
+		return joinPath([]byte{byte(only)}, b.children[only])
+	case b.value != nil:
+		return &patriciaLeaf{path: []byte{}, value: b.value}
+	default:
+		return nil
+	}
+}
+
+// joinPath prefixes the path of the given node with the given path.
+func joinPath(path []byte, node patriciaNode) patriciaNode {
+	switch n := node.(type) {
+	case *patriciaLeaf:
+		return &patriciaLeaf{path: concatNibbles(path, n.path), value: n.value}
+	case *patriciaExtension:
+		return &patriciaExtension{path: concatNibbles(path, n.path), child: n.child}
+	case *patriciaBranch:
+		return &patriciaExtension{path: path, child: n}
+	default:
+		return nil
+	}
+}
+
+// encode returns the RLP encoding of the given node.
+func (t *PatriciaTrie) encode(node patriciaNode) []byte {
+	switch n := node.(type) {
+	case *patriciaLeaf:
+		return rlpEncodeList(
+			rlpEncodeString(hexPrefixEncode(n.path, true)),
+			rlpEncodeString(n.value),
+		)
+	case *patriciaExtension:
+		return rlpEncodeList(
+			rlpEncodeString(hexPrefixEncode(n.path, false)),
+			t.ref(n.child),
+		)
+	case *patriciaBranch:
+		items := make([][]byte, 17)
+		for i, child := range n.children {
+			items[i] = t.ref(child)
+		}
+		items[16] = rlpEncodeString(n.value)
+		return rlpEncodeList(items...)
+	default:
+		return rlpEncodeString(nil)
+	}
+}
+
+// ref returns how the given node is referenced by its parent, which is either
+// its hash or, if its encoding is shorter than a hash, the encoding itself.
+func (t *PatriciaTrie) ref(node patriciaNode) []byte {
+	if node == nil {
+		return rlpEncodeString(nil)
+	}
+
+	enc := t.encode(node)
+	if len(enc) < t.hasher.Size() {
+		return enc
+	}
+	return rlpEncodeString(patriciaHash(t.hasher, enc))
+}
+
+func patriciaHash(hasher hash.Hash, enc []byte) []byte {
+	hasher.Reset()
+	hasher.Write(enc)
+	return hasher.Sum(nil)
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 2*len(key))
+	for i, b := range key {
+		nibbles[2*i] = b >> 4
+		nibbles[2*i+1] = b & 0x0f
+	}
+	return nibbles
+}
+
+func concatNibbles(a, b []byte) []byte {
+	return append(append(make([]byte, 0, len(a)+len(b)), a...), b...)
+}
+
+func commonPrefixLen(a, b []byte) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// hexPrefixEncode encodes a nibble path along with a flag which marks leaves
+// as described in Appendix C of the Ethereum yellow paper.
+func hexPrefixEncode(nibbles []byte, leaf bool) []byte {
+	var flag byte
+	if leaf {
+		flag = 2
+	}
+
+	b := make([]byte, 0, len(nibbles)/2+1)
+	if len(nibbles)%2 != 0 {
+		b = append(b, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		b = append(b, flag<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		b = append(b, nibbles[i]<<4|nibbles[i+1])
+	}
+	return b
+}
+
+func hexPrefixDecode(b []byte) (nibbles []byte, leaf bool, ok bool) {
+	if len(b) == 0 {
+		return nil, false, false
+	}
+
+	flag := b[0] >> 4
+	if flag > 3 {
+		return nil, false, false
+	}
+	if flag&1 == 0 && b[0]&0x0f != 0 {
+		return nil, false, false
+	}
+
+	if flag&1 != 0 {
+		nibbles = append(nibbles, b[0]&0x0f)
+	}
+	nibbles = append(nibbles, keyToNibbles(b[1:])...)
+	return nibbles, flag&2 != 0, true
+}
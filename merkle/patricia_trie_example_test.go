@@ -0,0 +1,31 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"fmt"
+)
+
+func ExamplePatriciaTrie() {
+	// Ethereum compatible roots require a legacy Keccak-256 implementation,
+	// e.g. sha3.NewLegacyKeccak256 from golang.org/x/crypto.
+	trie := NewPatriciaTrie(newKeccak256)
+	trie.Set([]byte("doe"), []byte("reindeer"))
+	trie.Set([]byte("dog"), []byte("puppy"))
+	trie.Set([]byte("dogglesworth"), []byte("cat"))
+	root := trie.Root()
+
+	value, err := VerifyPatriciaProof(newKeccak256, root, []byte("dog"), trie.Prove([]byte("dog")))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%x\n", root)
+	fmt.Println(string(value))
+	// Output: 8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3
+	// puppy
+}
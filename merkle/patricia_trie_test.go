@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatriciaTrie_Root(t *testing.T) {
+	type entry struct {
+		Key   string
+		Value string
+	}
+
+	testCases := []struct {
+		Name     string
+		Entries  []entry
+		Expected string
+	}{
+		{
+			Name:     "if the trie is empty",
+			Expected: "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421",
+		},
+		{
+			Name: "if keys share a prefix",
+			Entries: []entry{
+				{Key: "doe", Value: "reindeer"},
+				{Key: "dog", Value: "puppy"},
+				{Key: "dogglesworth", Value: "cat"},
+			},
+			Expected: "8aad789dff2f538bca5d8ea56e8abe10f4c7ba3a5dea95fea4cd6e7c3a1168d3",
+		},
+		{
+			Name: "if keys are deleted by setting an empty value",
+			Entries: []entry{
+				{Key: "do", Value: "verb"},
+				{Key: "ether", Value: "wookiedoo"},
+				{Key: "horse", Value: "stallion"},
+				{Key: "shaman", Value: "horse"},
+				{Key: "doge", Value: "coin"},
+				{Key: "ether", Value: ""},
+				{Key: "dog", Value: "puppy"},
+				{Key: "shaman", Value: ""},
+			},
+			Expected: "5991bb8c6514148a29db676a14ac506cd2cd5775ace63c30a4fe457715e9ac84",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("will match ethereum "+testCase.Name, func(t *testing.T) {
+			trie := NewPatriciaTrie(newKeccak256)
+			for _, e := range testCase.Entries {
+				trie.Set([]byte(e.Key), []byte(e.Value))
+			}
+
+			require.Equal(t, testCase.Expected, hex.EncodeToString(trie.Root()))
+		})
+	}
+}
+
+func TestPatriciaTrie_Get(t *testing.T) {
+	trie := NewPatriciaTrie(newKeccak256)
+	trie.Set([]byte("do"), []byte("verb"))
+	trie.Set([]byte("dog"), []byte("puppy"))
+	trie.Set([]byte("doge"), []byte("coin"))
+	trie.Set([]byte("horse"), []byte("stallion"))
+
+	t.Run("will return the value of a contained key", func(t *testing.T) {
+		v, ok := trie.Get([]byte("dog"))
+		require.True(t, ok)
+		require.Equal(t, []byte("puppy"), v)
+
+		v, ok = trie.Get([]byte("do"))
+		require.True(t, ok)
+		require.Equal(t, []byte("verb"), v)
+	})
+
+	t.Run("will not find a missing key", func(t *testing.T) {
+		for _, key := range []string{"", "d", "dogs", "horses", "cat"} {
+			_, ok := trie.Get([]byte(key))
+			require.False(t, ok, key)
+		}
+	})
+}
+
+func TestPatriciaTrie_Delete(t *testing.T) {
+	t.Run("will restore the previous root", func(t *testing.T) {
+		trie := NewPatriciaTrie(newKeccak256)
+		empty := trie.Root()
+
+		for i := range 100 {
+			trie.Set([]byte(strconv.Itoa(i)), []byte("value"+strconv.Itoa(i)))
+		}
+		full := trie.Root()
+
+		for i := 100; i < 200; i++ {
+			trie.Set([]byte(strconv.Itoa(i)), []byte("value"+strconv.Itoa(i)))
+		}
+		for i := 100; i < 200; i++ {
+			trie.Delete([]byte(strconv.Itoa(i)))
+		}
+		require.Equal(t, full, trie.Root())
+
+		for i := range 100 {
+			trie.Delete([]byte(strconv.Itoa(i)))
+		}
+		require.Equal(t, empty, trie.Root())
+		require.Nil(t, trie.root)
+	})
+
+	t.Run("will do nothing if the key is not contained in the trie", func(t *testing.T) {
+		trie := NewPatriciaTrie(newKeccak256)
+		trie.Set([]byte("dog"), []byte("puppy"))
+		root := trie.Root()
+
+		trie.Delete([]byte("do"))
+		trie.Delete([]byte("doge"))
+		require.Equal(t, root, trie.Root())
+	})
+}
+
+func TestPatriciaTrie_Prove(t *testing.T) {
+	trie := NewPatriciaTrie(newKeccak256)
+	for i := range 100 {
+		trie.Set([]byte(strconv.Itoa(i)), []byte("value"+strconv.Itoa(i)))
+	}
+	root := trie.Root()
+
+	t.Run("will prove the value of every key", func(t *testing.T) {
+		for i := range 100 {
+			key := []byte(strconv.Itoa(i))
+
+			v, err := VerifyPatriciaProof(newKeccak256, root, key, trie.Prove(key))
+			require.Nil(t, err)
+			require.Equal(t, []byte("value"+strconv.Itoa(i)), v)
+		}
+	})
+
+	t.Run("will prove the absence of missing keys", func(t *testing.T) {
+		for _, key := range []string{"", "100", "1000", "abc"} {
+			v, err := VerifyPatriciaProof(newKeccak256, root, []byte(key), trie.Prove([]byte(key)))
+			require.Nil(t, err)
+			require.Nil(t, v)
+		}
+	})
+
+	t.Run("will prove the absence of a key in an empty trie", func(t *testing.T) {
+		empty := NewPatriciaTrie(newKeccak256)
+
+		v, err := VerifyPatriciaProof(newKeccak256, empty.Root(), []byte("a"), empty.Prove([]byte("a")))
+		require.Nil(t, err)
+		require.Nil(t, v)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is missing a node", func(t *testing.T) {
+			proof := trie.Prove([]byte("42"))
+			require.Greater(t, len(proof), 1)
+
+			_, err := VerifyPatriciaProof(newKeccak256, root, []byte("42"), proof[:len(proof)-1])
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the proof is for a different root", func(t *testing.T) {
+			other := NewPatriciaTrie(newKeccak256)
+			other.Set([]byte("42"), []byte("value42"))
+
+			_, err := VerifyPatriciaProof(newKeccak256, other.Root(), []byte("42"), trie.Prove([]byte("42")))
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if a node is malformed", func(t *testing.T) {
+			proof := [][]byte{{0xc2, 0x80}}
+			hasher := newKeccak256()
+			hasher.Write(proof[0])
+
+			_, err := VerifyPatriciaProof(newKeccak256, hasher.Sum(nil), []byte("42"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		branch := make([][]byte, 17)
+		for i := range 16 {
+			branch[i] = rlpEncodeString(nil)
+		}
+		branch[16] = rlpEncodeList()
+
+		testCases := []struct {
+			Name string
+			Node []byte
+		}{
+			{
+				Name: "if the value of a leaf is a list",
+				Node: rlpEncodeList(rlpEncodeString(hexPrefixEncode(keyToNibbles([]byte("42")), true)), rlpEncodeList()),
+			},
+			{
+				Name: "if the value of a branch is a list",
+				Node: rlpEncodeList(branch...),
+			},
+			{
+				Name: "if an extension has no child",
+				Node: rlpEncodeList(rlpEncodeString(hexPrefixEncode(keyToNibbles([]byte("4")), false)), rlpEncodeString(nil)),
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				hasher := newKeccak256()
+				hasher.Write(testCase.Node)
+
+				for _, key := range []string{"42", "x"} {
+					_, err := VerifyPatriciaProof(newKeccak256, hasher.Sum(nil), []byte(key), [][]byte{testCase.Node})
+					require.ErrorIs(t, err, ErrInvalidProof)
+				}
+			})
+		}
+	})
+}
+
+func TestRLP(t *testing.T) {
+	t.Run("will round trip strings and lists", func(t *testing.T) {
+		long := make([]byte, 1024)
+		enc := rlpEncodeList(
+			rlpEncodeString(nil),
+			rlpEncodeString([]byte{0x7f}),
+			rlpEncodeString([]byte("dog")),
+			rlpEncodeString(long),
+		)
+
+		item, rest, err := rlpSplit(enc)
+		require.Nil(t, err)
+		require.Empty(t, rest)
+		require.True(t, item.list)
+
+		items, err := rlpListItems(item.payload)
+		require.Nil(t, err)
+		require.Len(t, items, 4)
+		require.Empty(t, items[0].payload)
+		require.Equal(t, []byte{0x7f}, items[1].payload)
+		require.Equal(t, []byte("dog"), items[2].payload)
+		require.Equal(t, long, items[3].payload)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the input is truncated", func(t *testing.T) {
+			_, _, err := rlpSplit(rlpEncodeString([]byte("dog"))[:2])
+			require.ErrorIs(t, err, errMalformedRLP)
+		})
+
+		t.Run("if the input is empty", func(t *testing.T) {
+			_, _, err := rlpSplit(nil)
+			require.ErrorIs(t, err, errMalformedRLP)
+		})
+	})
+}
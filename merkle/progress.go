@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Progress describes how far along the construction of a [BinaryTree] is.
+type Progress struct {
+	// TotalLeaves is the number of leaves the tree is being constructed from.
+	TotalLeaves int
+
+	// LeavesHashed is the number of leaves which have been completely hashed.
+	LeavesHashed int
+
+	// BytesHashed is the number of leaf bytes which have been hashed.
+	BytesHashed int64
+
+	// Level is the level of the tree currently being hashed, where
+	// level 0 is the leaves and the root is at the highest level.
+	Level int
+}
+
+// WithProgress registers a func which is called every time progress is made
+// while constructing a [BinaryTree] i.e. after reading from a leaf, after a leaf
+// has been completely hashed and when moving on to the next level of the tree.
+// The func is never called concurrently, even when constructing in parallel,
+// so it should return quickly.
+func WithProgress(f func(Progress)) BinaryTreeOption {
+	return progressOption(f)
+}
+
+type progressOption func(Progress)
+
+func (f progressOption) applyBinaryTree(o *binaryTreeOptions) {
+	o.progress = f
+}
+
+func (f progressOption) applyParallel(o *parallelOptions) {
+	f.applyBinaryTree(&o.binaryTreeOptions)
+}
+
+// progressTracker accumulates the progress made by one or more
+// goroutines and serially reports it.
+type progressTracker struct {
+	mu       sync.Mutex
+	progress Progress
+	report   func(Progress)
+}
+
+func newProgressTracker(report func(Progress), totalLeaves int) *progressTracker {
+	return &progressTracker{
+		progress: Progress{
+			TotalLeaves: totalLeaves,
+		},
+		report: report,
+	}
+}
+
+func (t *progressTracker) update(f func(*Progress)) {
+	if t.report == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f(&t.progress)
+	t.report(t.progress)
+}
+
+func (t *progressTracker) bytesHashed(n int) {
+	t.update(func(p *Progress) {
+		p.BytesHashed += int64(n)
+	})
+}
+
+func (t *progressTracker) leafHashed() {
+	t.update(func(p *Progress) {
+		p.LeavesHashed += 1
+	})
+}
+
+func (t *progressTracker) level(level int) {
+	t.update(func(p *Progress) {
+		p.Level = level
+	})
+}
+
+// leafReader aborts reading a leaf as soon as its context is cancelled
+// and tracks how many bytes have been read from it.
+type leafReader struct {
+	ctx     context.Context
+	r       io.Reader
+	tracker *progressTracker
+}
+
+func (r leafReader) Read(b []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.tracker.bytesHashed(n)
+	}
+	return n, err
+}
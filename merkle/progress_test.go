@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBinaryTreeContext(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if no leaves are provided", func(t *testing.T) {
+			tree, err := NewBinaryTreeContext[io.Reader](t.Context(), sha256.New(), nil)
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+			require.Nil(t, tree)
+		})
+
+		t.Run("if the context is cancelled while reading a leaf", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			var reads int
+			endless := readFunc(func(b []byte) (int, error) {
+				reads += 1
+				cancel()
+				return len(b), nil
+			})
+
+			tree, err := NewBinaryTreeContext(ctx, sha256.New(), []io.Reader{endless})
+			require.ErrorIs(t, err, context.Canceled)
+			require.Nil(t, tree)
+			require.Equal(t, 1, reads)
+		})
+
+		t.Run("if the context is cancelled while hashing interior nodes", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			progress := WithProgress(func(p Progress) {
+				if p.Level > 0 {
+					cancel()
+				}
+			})
+
+			tree, err := NewBinaryTreeContext(ctx, sha256.New(), leafValues(8), progress)
+			require.ErrorIs(t, err, context.Canceled)
+			require.Nil(t, tree)
+		})
+	})
+
+	t.Run("will report progress", func(t *testing.T) {
+		constructors := map[string]func(context.Context, []*strings.Reader, ...BinaryTreeOption) (*BinaryTree, error){
+			"sequentially": func(ctx context.Context, leafs []*strings.Reader, opts ...BinaryTreeOption) (*BinaryTree, error) {
+				return NewBinaryTreeContext(ctx, sha256.New(), leafs, opts...)
+			},
+			"in parallel": func(ctx context.Context, leafs []*strings.Reader, opts ...BinaryTreeOption) (*BinaryTree, error) {
+				parallelOpts := []ParallelOption{WithMaxWorkers(4)}
+				for _, opt := range opts {
+					parallelOpts = append(parallelOpts, opt)
+				}
+				return NewBinaryTreeParallel(ctx, sha256.New, leafs, parallelOpts...)
+			},
+		}
+
+		for name, construct := range constructors {
+			t.Run(name, func(t *testing.T) {
+				leafs := leafValues(12)
+
+				var reports []Progress
+				tree, err := construct(t.Context(), leafs, WithProgress(func(p Progress) {
+					reports = append(reports, p)
+				}))
+				require.Nil(t, err)
+				require.NotEmpty(t, reports)
+
+				for i := 1; i < len(reports); i++ {
+					require.GreaterOrEqual(t, reports[i].LeavesHashed, reports[i-1].LeavesHashed)
+					require.GreaterOrEqual(t, reports[i].BytesHashed, reports[i-1].BytesHashed)
+					require.GreaterOrEqual(t, reports[i].Level, reports[i-1].Level)
+				}
+
+				expected, err := ConstructBinaryTree(sha256.New(), leafValues(12)...)
+				require.Nil(t, err)
+				require.Equal(t, expected.String(), tree.String())
+
+				last := reports[len(reports)-1]
+				require.Equal(t, Progress{
+					TotalLeaves:  12,
+					LeavesHashed: 12,
+					BytesHashed:  14,
+					Level:        4,
+				}, last)
+			})
+		}
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"io"
+	"math/bits"
+)
+
+// Side describes which side of a node a sibling hash is located on.
+type Side uint8
+
+const (
+	// LeftSide means the sibling hash must be placed before the
+	// current hash when computing their parent hash.
+	LeftSide Side = iota
+
+	// RightSide means the sibling hash must be placed after the
+	// current hash when computing their parent hash.
+	RightSide
+)
+
+// ProofNode is a single sibling hash along an audit path.
+type ProofNode struct {
+	Hash []byte
+	Side Side
+}
+
+// InclusionProof is an audit path which proves a leaf is contained
+// in a [BinaryTree] with a specific root hash.
+type InclusionProof struct {
+	// LeafIndex is the index of the proven leaf.
+	LeafIndex int
+
+	// TreeSize is the number of leaves in the tree the proof was generated from.
+	TreeSize int
+
+	// Path contains the sibling hashes ordered from the leaf up to the root.
+	Path []ProofNode
+}
+
+var (
+	// ErrLeafIndexOutOfRange is returned if a leaf index does not refer to a leaf in the tree.
+	ErrLeafIndexOutOfRange = errors.New("leaf index out of range")
+
+	// ErrInvalidProof is returned if a proof is malformed for the leaf index and tree size it claims.
+	ErrInvalidProof = errors.New("invalid proof")
+
+	// ErrRootMismatch is returned if the root hash computed from a proof does not match the expected root hash.
+	ErrRootMismatch = errors.New("computed root does not match expected root")
+)
+
+// Prove returns an [InclusionProof] for the leaf at the given index.
+func (t *BinaryTree) Prove(leafIndex int) (*InclusionProof, error) {
+	if leafIndex < 0 || leafIndex >= t.size {
+		return nil, ErrLeafIndexOutOfRange
+	}
+
+	proof := &InclusionProof{
+		LeafIndex: leafIndex,
+		TreeSize:  t.size,
+		Path:      make([]ProofNode, 0, bits.Len(uint(t.size))),
+	}
+
+	node := t
+	idx := leafIndex
+	for !node.IsLeaf() {
+		if idx < node.left.size {
+			proof.Path = append(proof.Path, ProofNode{
+				Hash: node.right.hash,
+				Side: RightSide,
+			})
+			node = node.left
+			continue
+		}
+
+		proof.Path = append(proof.Path, ProofNode{
+			Hash: node.left.hash,
+			Side: LeftSide,
+		})
+		idx -= node.left.size
+		node = node.right
+	}
+
+	// path was collected from the root down but is verified from the leaf up
+	for i, j := 0, len(proof.Path)-1; i < j; i, j = i+1, j-1 {
+		proof.Path[i], proof.Path[j] = proof.Path[j], proof.Path[i]
+	}
+	return proof, nil
+}
+
+// VerifyInclusion verifies that the given leaf is contained in the tree with
+// the given root hash. The hasher and options must be the same as those used to
+// construct the tree. A nil error is only returned if the proof is valid.
+func VerifyInclusion(hasher hash.Hash, root []byte, leaf io.Reader, proof *InclusionProof, opts ...Option) error {
+	if proof == nil {
+		return ErrInvalidProof
+	}
+	if proof.LeafIndex < 0 || proof.LeafIndex >= proof.TreeSize {
+		return ErrInvalidProof
+	}
+
+	sides := auditPathSides(proof.LeafIndex, proof.TreeSize)
+	if len(sides) != len(proof.Path) {
+		return ErrInvalidProof
+	}
+
+	o := applyOptions(opts)
+
+	hash, err := o.scheme.hashLeaf(hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	for i, node := range proof.Path {
+		if node.Side != sides[i] {
+			return ErrInvalidProof
+		}
+
+		switch node.Side {
+		case LeftSide:
+			hash, err = o.scheme.hashChildren(hasher, node.Hash, hash)
+		case RightSide:
+			hash, err = o.scheme.hashChildren(hasher, hash, node.Hash)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if !bytes.Equal(hash, root) {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+// auditPathSides returns the sides of every sibling along the audit path,
+// ordered from the leaf up to the root, for the leaf at the given index
+// in a tree of the given size.
+func auditPathSides(leafIndex, treeSize int) []Side {
+	var sides []Side
+	for treeSize > 1 {
+		k := splitPoint(treeSize)
+		if leafIndex < k {
+			sides = append(sides, RightSide)
+			treeSize = k
+			continue
+		}
+
+		sides = append(sides, LeftSide)
+		leafIndex -= k
+		treeSize -= k
+	}
+
+	for i, j := 0, len(sides)-1; i < j; i, j = i+1, j-1 {
+		sides[i], sides[j] = sides[j], sides[i]
+	}
+	return sides
+}
+
+// splitPoint returns the largest power of 2 which is strictly less than n.
+// This is the number of leaves contained in the left child of a tree with
+// n leaves.
+func splitPoint(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
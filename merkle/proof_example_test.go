@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func ExampleVerifyInclusion() {
+	tree, err := ConstructBinaryTree(
+		sha256.New(),
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+		strings.NewReader("c"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	proof, err := tree.Prove(1)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("b"), proof)
+	fmt.Println(err)
+
+	err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("z"), proof)
+	fmt.Println(err)
+
+	// Output: <nil>
+	// computed root does not match expected root
+}
+
+func ExampleVerifyConsistency() {
+	oldLeafs := []io.Reader{
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+	}
+
+	oldTree, err := NewBinaryTree(sha256.New(), oldLeafs, WithScheme(RFC6962Scheme))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	newLeafs := []io.Reader{
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+		strings.NewReader("c"),
+	}
+
+	newTree, err := NewBinaryTree(sha256.New(), newLeafs, WithScheme(RFC6962Scheme))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	proof, err := newTree.ProveConsistency(2)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = VerifyConsistency(sha256.New(), 2, 3, oldTree.Hash(), newTree.Hash(), proof, WithScheme(RFC6962Scheme))
+	fmt.Println(err)
+
+	// Output: <nil>
+}
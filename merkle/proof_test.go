@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func leafValues(n int) []*strings.Reader {
+	leafs := make([]*strings.Reader, n)
+	for i := range n {
+		leafs[i] = strings.NewReader(strconv.Itoa(i))
+	}
+	return leafs
+}
+
+func TestBinaryTree_Prove(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the leaf index is negative", func(t *testing.T) {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(3)...)
+			require.Nil(t, err)
+
+			proof, err := tree.Prove(-1)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+			require.Nil(t, proof)
+		})
+
+		t.Run("if the leaf index is greater than the number of leaves", func(t *testing.T) {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(3)...)
+			require.Nil(t, err)
+
+			proof, err := tree.Prove(3)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+			require.Nil(t, proof)
+		})
+	})
+
+	t.Run("will return a proof which can be verified", func(t *testing.T) {
+		for _, numOfLeafs := range []int{1, 2, 3, 4, 5, 7, 8, 13, 64} {
+			t.Run("for every leaf in a tree with "+strconv.Itoa(numOfLeafs)+" leaves", func(t *testing.T) {
+				tree, err := ConstructBinaryTree(sha256.New(), leafValues(numOfLeafs)...)
+				require.Nil(t, err)
+
+				for i := range numOfLeafs {
+					proof, err := tree.Prove(i)
+					require.Nil(t, err)
+					require.Equal(t, i, proof.LeafIndex)
+					require.Equal(t, numOfLeafs, proof.TreeSize)
+
+					err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader(strconv.Itoa(i)), proof)
+					require.Nil(t, err)
+				}
+			})
+		}
+	})
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	tree, err := ConstructBinaryTree(sha256.New(), leafValues(7)...)
+	require.Nil(t, err)
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is nil", func(t *testing.T) {
+			err := VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("0"), nil)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the leaf does not match", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("3"), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the root does not match", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			root := bytes.Clone(tree.Hash())
+			root[0] ^= 0xff
+
+			err = VerifyInclusion(sha256.New(), root, strings.NewReader("2"), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if a sibling hash has been modified", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			proof.Path[1].Hash = bytes.Clone(proof.Path[1].Hash)
+			proof.Path[1].Hash[0] ^= 0xff
+
+			err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("2"), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the leaf index does not match the audit path", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			proof.LeafIndex = 3
+
+			err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("2"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the audit path has been truncated", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			proof.Path = proof.Path[:len(proof.Path)-1]
+
+			err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("2"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the leaf fails to be read", func(t *testing.T) {
+			proof, err := tree.Prove(2)
+			require.Nil(t, err)
+
+			leaf := readFunc(func(b []byte) (int, error) {
+				return 0, errReadFailed
+			})
+
+			err = VerifyInclusion(sha256.New(), tree.Hash(), leaf, proof)
+			require.ErrorIs(t, err, errReadFailed)
+		})
+	})
+}
+
+func TestSplitPoint(t *testing.T) {
+	t.Run("will match the shape of a constructed tree", func(t *testing.T) {
+		for numOfLeafs := 2; numOfLeafs <= 100; numOfLeafs++ {
+			tree, err := ConstructBinaryTree(sha256.New(), leafValues(numOfLeafs)...)
+			require.Nil(t, err)
+
+			var check func(*BinaryTree)
+			check = func(node *BinaryTree) {
+				if node.IsLeaf() {
+					return
+				}
+
+				require.Equal(t, splitPoint(node.size), node.left.size)
+				check(node.left)
+				check(node.right)
+			}
+			check(tree)
+		}
+	})
+}
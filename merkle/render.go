@@ -0,0 +1,242 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// DefaultRenderHashLength is the number of hex characters of every hash shown
+// by [BinaryTree.RenderDOT] and [BinaryTree.RenderASCII], unless a different
+// length is set with [WithHashLength].
+const DefaultRenderHashLength = 8
+
+// RenderOption configures [BinaryTree.RenderDOT] and [BinaryTree.RenderASCII].
+type RenderOption interface {
+	applyRender(*renderOptions)
+}
+
+type renderOptions struct {
+	hashLength int
+	highlight  *InclusionProof
+}
+
+type hashLengthOption int
+
+func (n hashLengthOption) applyRender(o *renderOptions) {
+	o.hashLength = int(n)
+}
+
+// WithHashLength sets the number of hex characters shown for every hash when
+// rendering a tree. Hashes are never padded, so a large length shows full hashes.
+func WithHashLength(n int) RenderOption {
+	return hashLengthOption(n)
+}
+
+type highlightedProofOption struct {
+	proof *InclusionProof
+}
+
+func (opt highlightedProofOption) applyRender(o *renderOptions) {
+	o.highlight = opt.proof
+}
+
+// WithHighlightedProof highlights the audit path of the given proof when rendering
+// a tree. Besides the proven leaf, every node whose hash is computed by a verifier
+// and every node whose hash is part of the proof is highlighted. Proof hashes which
+// differ from the hash of the node in the tree are shown alongside it.
+func WithHighlightedProof(proof *InclusionProof) RenderOption {
+	return highlightedProofOption{proof: proof}
+}
+
+type highlightRole uint8
+
+const (
+	highlightNone highlightRole = iota
+	highlightLeaf
+	highlightPath
+	highlightAuditPath
+)
+
+type highlight struct {
+	role highlightRole
+
+	// proofHash is the hash of the node in the proof, if it differs from the tree.
+	proofHash []byte
+}
+
+// RenderDOT writes this tree in the DOT language, which can be rendered by Graphviz,
+// e.g. with "dot -Tsvg". Every node is labelled with its truncated hash and the
+// indices of the leaves it contains.
+func (t *BinaryTree) RenderDOT(w io.Writer, opts ...RenderOption) error {
+	r, err := newTreeRenderer(t, opts)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph merkle {\n")
+	buf.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+
+	id := 0
+	var visit func(node *BinaryTree, start int) int
+	visit = func(node *BinaryTree, start int) int {
+		nodeID := id
+		id++
+
+		label := r.hash(node.hash) + "\\n" + leafRange(start, node.size)
+		attrs := ""
+		h := r.highlights[node]
+		switch h.role {
+		case highlightLeaf:
+			attrs = `, style=filled, fillcolor="palegreen"`
+		case highlightPath:
+			attrs = `, style=filled, fillcolor="lightblue"`
+		case highlightAuditPath:
+			attrs = `, style=filled, fillcolor="orange"`
+		}
+		if h.proofHash != nil {
+			label += "\\nproof: " + r.hash(h.proofHash)
+			attrs = `, style=filled, fillcolor="red"`
+		}
+		fmt.Fprintf(&buf, "\tn%d [label=\"%s\"%s];\n", nodeID, label, attrs)
+
+		if !node.IsLeaf() {
+			left := visit(node.left, start)
+			right := visit(node.right, start+node.left.size)
+			fmt.Fprintf(&buf, "\tn%d -> n%d;\n", nodeID, left)
+			fmt.Fprintf(&buf, "\tn%d -> n%d;\n", nodeID, right)
+		}
+		return nodeID
+	}
+	visit(t, 0)
+
+	buf.WriteString("}\n")
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// RenderASCII writes this tree as indented lines of text, one per node, where
+// every node is labelled with its truncated hash and the indices of the leaves
+// it contains. Highlighted nodes are annotated with their role in the proof.
+func (t *BinaryTree) RenderASCII(w io.Writer, opts ...RenderOption) error {
+	r, err := newTreeRenderer(t, opts)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	var visit func(node *BinaryTree, start int, branch, indent string)
+	visit = func(node *BinaryTree, start int, branch, indent string) {
+		buf.WriteString(branch + r.hash(node.hash) + " " + leafRange(start, node.size))
+
+		h := r.highlights[node]
+		switch h.role {
+		case highlightLeaf:
+			buf.WriteString(" [proven leaf")
+		case highlightPath:
+			buf.WriteString(" [path")
+		case highlightAuditPath:
+			buf.WriteString(" [audit path")
+		}
+		if h.proofHash != nil {
+			buf.WriteString(", proof has " + r.hash(h.proofHash))
+		}
+		if h.role != highlightNone {
+			buf.WriteString("]")
+		}
+		buf.WriteString("\n")
+
+		if !node.IsLeaf() {
+			visit(node.left, start, indent+"├── ", indent+"│   ")
+			visit(node.right, start+node.left.size, indent+"└── ", indent+"    ")
+		}
+	}
+	visit(t, 0, "", "")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+type treeRenderer struct {
+	hashLength int
+	highlights map[*BinaryTree]highlight
+}
+
+func newTreeRenderer(t *BinaryTree, opts []RenderOption) (*treeRenderer, error) {
+	var o renderOptions
+	for _, opt := range opts {
+		opt.applyRender(&o)
+	}
+
+	r := &treeRenderer{
+		hashLength: o.hashLength,
+	}
+	if r.hashLength <= 0 {
+		r.hashLength = DefaultRenderHashLength
+	}
+	if o.highlight == nil {
+		return r, nil
+	}
+
+	proof := o.highlight
+	if proof.TreeSize != t.size || proof.LeafIndex < 0 || proof.LeafIndex >= t.size {
+		return nil, ErrInvalidProof
+	}
+
+	// walk down to the leaf, collecting the siblings of every node on the way,
+	// which are then ordered from the leaf up to the root like the proof
+	r.highlights = map[*BinaryTree]highlight{t: {role: highlightPath}}
+	var siblings []*BinaryTree
+	node, i := t, proof.LeafIndex
+	for !node.IsLeaf() {
+		if i < node.left.size {
+			siblings = append(siblings, node.right)
+			node = node.left
+		} else {
+			i -= node.left.size
+			siblings = append(siblings, node.left)
+			node = node.right
+		}
+		r.highlights[node] = highlight{role: highlightPath}
+	}
+	r.highlights[node] = highlight{role: highlightLeaf}
+
+	slices.Reverse(siblings)
+	if len(siblings) != len(proof.Path) {
+		return nil, ErrInvalidProof
+	}
+	for i, sibling := range siblings {
+		h := highlight{role: highlightAuditPath}
+		if !bytes.Equal(sibling.hash, proof.Path[i].Hash) {
+			h.proofHash = proof.Path[i].Hash
+		}
+		r.highlights[sibling] = h
+	}
+	return r, nil
+}
+
+// hash returns the truncated hex encoding of the given hash.
+func (r *treeRenderer) hash(b []byte) string {
+	s := hex.EncodeToString(b)
+	if len(s) > r.hashLength {
+		return s[:r.hashLength]
+	}
+	return s
+}
+
+// leafRange describes the n leaves starting at the given index.
+func leafRange(start, n int) string {
+	if n == 1 {
+		return fmt.Sprintf("leaf %d", start)
+	}
+	return fmt.Sprintf("leaves %d-%d", start, start+n-1)
+}
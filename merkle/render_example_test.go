@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func ExampleBinaryTree_RenderASCII() {
+	tree, err := NewBinaryTree(sha256.New(), []*strings.Reader{
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+		strings.NewReader("c"),
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	proof, err := tree.Prove(2)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = tree.RenderASCII(os.Stdout, WithHighlightedProof(proof))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Output:
+	// 7075152d leaves 0-2 [path]
+	// ├── e5a01fee leaves 0-1 [audit path]
+	// │   ├── ca978112 leaf 0
+	// │   └── 3e23e816 leaf 1
+	// └── 2e7d2c03 leaf 2 [proven leaf]
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryTree_RenderASCII(t *testing.T) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(3))
+	require.Nil(t, err)
+
+	t.Run("will render every node with its hash and leaves", func(t *testing.T) {
+		var sb strings.Builder
+		err := tree.RenderASCII(&sb)
+		require.Nil(t, err)
+
+		expected := `c80f7738 leaves 0-2
+├── b9b10a1b leaves 0-1
+│   ├── 5feceb66 leaf 0
+│   └── 6b86b273 leaf 1
+└── d4735e3a leaf 2
+`
+		require.Equal(t, expected, sb.String())
+	})
+
+	t.Run("will highlight the audit path of a proof", func(t *testing.T) {
+		proof, err := tree.Prove(1)
+		require.Nil(t, err)
+
+		var sb strings.Builder
+		err = tree.RenderASCII(&sb, WithHighlightedProof(proof), WithHashLength(4))
+		require.Nil(t, err)
+
+		expected := `c80f leaves 0-2 [path]
+├── b9b1 leaves 0-1 [path]
+│   ├── 5fec leaf 0 [audit path]
+│   └── 6b86 leaf 1 [proven leaf]
+└── d473 leaf 2 [audit path]
+`
+		require.Equal(t, expected, sb.String())
+	})
+
+	t.Run("will show proof hashes which differ from the tree", func(t *testing.T) {
+		proof, err := tree.Prove(2)
+		require.Nil(t, err)
+		proof.Path[0].Hash = make([]byte, sha256.Size)
+
+		var sb strings.Builder
+		err = tree.RenderASCII(&sb, WithHighlightedProof(proof), WithHashLength(100))
+		require.Nil(t, err)
+		require.Contains(t, sb.String(), "leaves 0-1 [audit path, proof has "+strings.Repeat("0", 2*sha256.Size)+"]\n")
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof is for a different tree size", func(t *testing.T) {
+			proof, err := tree.Prove(0)
+			require.Nil(t, err)
+			proof.TreeSize = 4
+
+			err = tree.RenderASCII(&strings.Builder{}, WithHighlightedProof(proof))
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the audit path has the wrong length", func(t *testing.T) {
+			proof, err := tree.Prove(0)
+			require.Nil(t, err)
+			proof.Path = proof.Path[1:]
+
+			err = tree.RenderASCII(&strings.Builder{}, WithHighlightedProof(proof))
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the writer fails", func(t *testing.T) {
+			err := tree.RenderASCII(writeFunc(func(b []byte) (int, error) {
+				return 0, errWriteFailed
+			}))
+			require.ErrorIs(t, err, errWriteFailed)
+		})
+	})
+}
+
+func TestBinaryTree_RenderDOT(t *testing.T) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(3))
+	require.Nil(t, err)
+
+	t.Run("will render every node and edge", func(t *testing.T) {
+		proof, err := tree.Prove(2)
+		require.Nil(t, err)
+
+		var sb strings.Builder
+		err = tree.RenderDOT(&sb, WithHighlightedProof(proof))
+		require.Nil(t, err)
+
+		expected := `digraph merkle {
+	node [shape=box, fontname="monospace"];
+	n0 [label="c80f7738\nleaves 0-2", style=filled, fillcolor="lightblue"];
+	n1 [label="b9b10a1b\nleaves 0-1", style=filled, fillcolor="orange"];
+	n2 [label="5feceb66\nleaf 0"];
+	n3 [label="6b86b273\nleaf 1"];
+	n1 -> n2;
+	n1 -> n3;
+	n4 [label="d4735e3a\nleaf 2", style=filled, fillcolor="palegreen"];
+	n0 -> n1;
+	n0 -> n4;
+}
+`
+		require.Equal(t, expected, sb.String())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the proof leaf index is out of range", func(t *testing.T) {
+			err := tree.RenderDOT(&strings.Builder{}, WithHighlightedProof(&InclusionProof{LeafIndex: 3, TreeSize: 3}))
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the writer fails", func(t *testing.T) {
+			err := tree.RenderDOT(writeFunc(func(b []byte) (int, error) {
+				return 0, errWriteFailed
+			}))
+			require.ErrorIs(t, err, errWriteFailed)
+		})
+	})
+}
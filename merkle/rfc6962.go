@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import "hash"
+
+// RFC6962Scheme produces Merkle Tree Hashes as defined by RFC 6962 and used by
+// Certificate Transparency logs and the Go checksum database.
+//
+// Trees constructed by this package always place the largest power of 2 number
+// of leaves, which is strictly less than the total number of leaves, in the left
+// subtree. This is the same shape as required by RFC 6962, so combining it with
+// domain separated hashing is all that is needed for root hashes and audit paths
+// to be bit-for-bit compatible.
+const RFC6962Scheme = DomainSeparatedScheme
+
+// EmptyTreeHash returns the RFC 6962 Merkle Tree Hash of a tree with zero leaves,
+// which is simply the hash of the empty string.
+func EmptyTreeHash(hasher hash.Hash) []byte {
+	hasher.Reset()
+	return hasher.Sum(nil)
+}
+
+// NewInclusionProof reconstructs an [InclusionProof] from a plain list of
+// sibling hashes, as returned by RFC 6962 compatible logs, ordered from the
+// leaf up to the root. The side of each sibling is derived from the leaf index
+// and tree size.
+func NewInclusionProof(leafIndex, treeSize int, hashes [][]byte) (*InclusionProof, error) {
+	if leafIndex < 0 || leafIndex >= treeSize {
+		return nil, ErrLeafIndexOutOfRange
+	}
+
+	sides := auditPathSides(leafIndex, treeSize)
+	if len(sides) != len(hashes) {
+		return nil, ErrInvalidProof
+	}
+
+	proof := &InclusionProof{
+		LeafIndex: leafIndex,
+		TreeSize:  treeSize,
+		Path:      make([]ProofNode, len(hashes)),
+	}
+	for i, hash := range hashes {
+		proof.Path[i] = ProofNode{
+			Hash: hash,
+			Side: sides[i],
+		}
+	}
+	return proof, nil
+}
+
+// Hashes returns the sibling hashes of the audit path, ordered from the leaf
+// up to the root, in the format used by RFC 6962 compatible logs.
+func (p *InclusionProof) Hashes() [][]byte {
+	hashes := make([][]byte, len(p.Path))
+	for i, node := range p.Path {
+		hashes[i] = node.Hash
+	}
+	return hashes
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6962Leaves are the leaf inputs used by the Certificate Transparency
+// reference implementations for their Merkle tree test vectors.
+var rfc6962Leaves = []string{
+	"",
+	"00",
+	"10",
+	"2021",
+	"3031",
+	"40414243",
+	"5051525354555657",
+	"606162636465666768696a6b6c6d6e6f",
+}
+
+// rfc6962Roots are the expected Merkle Tree Hashes of the first i+1 leaves
+// of rfc6962Leaves.
+var rfc6962Roots = []string{
+	"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+	"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+	"aeb6bcfe274b70a14fb067a5e5578264db0fa9b51af5e0ba159158f329e06e77",
+	"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+	"4e3bbb1f7b478dcfe71fb631631519a3bca12c9aefca1612bfce4c13a86264d4",
+	"76e67dadbcdf1e10e1b74ddc608abd2f98dfb16fbce75277b5232a127f2087ef",
+	"ddb89be403809e325750d3d263cd78929c2942b7942a34b77e122c9594a74c8c",
+	"5dc9da79a70659a9ad559cb701ded9a2ab9d823aad2f4960cfe370eff4604328",
+}
+
+func rfc6962LeafReaders(t *testing.T, n int) []*bytes.Reader {
+	leafs := make([]*bytes.Reader, n)
+	for i := range n {
+		b, err := hex.DecodeString(rfc6962Leaves[i])
+		require.Nil(t, err)
+
+		leafs[i] = bytes.NewReader(b)
+	}
+	return leafs
+}
+
+func decodeHexes(t *testing.T, hexes ...string) [][]byte {
+	hashes := make([][]byte, len(hexes))
+	for i, h := range hexes {
+		b, err := hex.DecodeString(h)
+		require.Nil(t, err)
+
+		hashes[i] = b
+	}
+	return hashes
+}
+
+func TestRFC6962Scheme(t *testing.T) {
+	t.Run("will produce the expected merkle tree hash", func(t *testing.T) {
+		for i, root := range rfc6962Roots {
+			tree, err := NewBinaryTree(sha256.New(), rfc6962LeafReaders(t, i+1), WithScheme(RFC6962Scheme))
+			require.Nil(t, err)
+			require.Equal(t, root, tree.String())
+		}
+	})
+
+	t.Run("will produce the expected audit paths", func(t *testing.T) {
+		testCases := []struct {
+			Name      string
+			LeafIndex int
+			TreeSize  int
+			Path      []string
+		}{
+			{
+				Name:      "for a tree with a single leaf",
+				LeafIndex: 0,
+				TreeSize:  1,
+			},
+			{
+				Name:      "for the first leaf of a full tree",
+				LeafIndex: 0,
+				TreeSize:  8,
+				Path: []string{
+					"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+					"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+					"6b47aaf29ee3c2af9af889bc1fb9254dabd31177f16232dd6aab035ca39bf6e4",
+				},
+			},
+			{
+				Name:      "for a leaf in the right subtree of a full tree",
+				LeafIndex: 5,
+				TreeSize:  8,
+				Path: []string{
+					"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+					"ca854ea128ed050b41b35ffc1b87b8eb2bde461e9e3b5596ece6b9d5975a0ae0",
+					"d37ee418976dd95753c1c73862b9398fa2a2cf9b4ff0fdfe8b30cd95209614b7",
+				},
+			},
+			{
+				Name:      "for the promoted last leaf of a partial tree",
+				LeafIndex: 2,
+				TreeSize:  3,
+				Path: []string{
+					"fac54203e7cc696cf0dfcb42c92a1d9dbaf70ad9e621f4bd8d98662f00e3c125",
+				},
+			},
+			{
+				Name:      "for a leaf in the left subtree of a partial tree",
+				LeafIndex: 1,
+				TreeSize:  5,
+				Path: []string{
+					"6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+					"5f083f0a1a33ca076a95279832580db3e0ef4584bdff1f54c8a360f50de3031e",
+					"bc1a0643b12e4d2d7c77918f44e0f4f79a838b6cf9ec5b5c283e1f4d88599e6b",
+				},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				leafs := rfc6962LeafReaders(t, testCase.TreeSize)
+				tree, err := NewBinaryTree(sha256.New(), leafs, WithScheme(RFC6962Scheme))
+				require.Nil(t, err)
+
+				proof, err := tree.Prove(testCase.LeafIndex)
+				require.Nil(t, err)
+
+				expected := decodeHexes(t, testCase.Path...)
+				require.Equal(t, expected, proof.Hashes())
+
+				proof, err = NewInclusionProof(testCase.LeafIndex, testCase.TreeSize, expected)
+				require.Nil(t, err)
+
+				leaf := rfc6962LeafReaders(t, testCase.TreeSize)[testCase.LeafIndex]
+				err = VerifyInclusion(sha256.New(), tree.Hash(), leaf, proof, WithScheme(RFC6962Scheme))
+				require.Nil(t, err)
+			})
+		}
+	})
+}
+
+func TestEmptyTreeHash(t *testing.T) {
+	t.Run("will return the hash of the empty string", func(t *testing.T) {
+		hasher := sha256.New()
+		hasher.Write([]byte("dirty state"))
+
+		hash := EmptyTreeHash(hasher)
+		require.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", hex.EncodeToString(hash))
+	})
+}
+
+func TestNewInclusionProof(t *testing.T) {
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			proof, err := NewInclusionProof(3, 3, nil)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+			require.Nil(t, proof)
+		})
+
+		t.Run("if the number of hashes does not match the audit path length", func(t *testing.T) {
+			proof, err := NewInclusionProof(0, 4, decodeHexes(t, rfc6962Roots[0]))
+			require.ErrorIs(t, err, ErrInvalidProof)
+			require.Nil(t, proof)
+		})
+	})
+}
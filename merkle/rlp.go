@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// errMalformedRLP is returned when decoding data which is not valid RLP.
+var errMalformedRLP = errors.New("malformed rlp")
+
+// rlpItem is a single decoded RLP item.
+type rlpItem struct {
+	list bool
+
+	// payload is the content of the item, excluding its header.
+	payload []byte
+
+	// raw is the complete encoding of the item, including its header.
+	raw []byte
+}
+
+func rlpEncodeString(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+func rlpEncodeList(items ...[]byte) []byte {
+	var size int
+	for _, item := range items {
+		size += len(item)
+	}
+
+	b := rlpHeader(0xc0, size)
+	for _, item := range items {
+		b = append(b, item...)
+	}
+	return b
+}
+
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+
+	var sizeBytes [8]byte
+	binary.BigEndian.PutUint64(sizeBytes[:], uint64(size))
+
+	i := 0
+	for sizeBytes[i] == 0 {
+		i++
+	}
+	return append([]byte{offset + 55 + byte(8-i)}, sizeBytes[i:]...)
+}
+
+// rlpSplit decodes the first item in b and returns it along with
+// the remaining bytes.
+func rlpSplit(b []byte) (rlpItem, []byte, error) {
+	if len(b) == 0 {
+		return rlpItem{}, nil, errMalformedRLP
+	}
+
+	prefix := b[0]
+	var item rlpItem
+	var headerSize, size int
+	switch {
+	case prefix < 0x80:
+		headerSize, size = 0, 1
+	case prefix < 0xb8:
+		headerSize, size = 1, int(prefix-0x80)
+	case prefix < 0xc0:
+		headerSize = 1 + int(prefix-0xb7)
+		size = rlpDecodeSize(b[1:], headerSize-1)
+	case prefix < 0xf8:
+		item.list = true
+		headerSize, size = 1, int(prefix-0xc0)
+	default:
+		item.list = true
+		headerSize = 1 + int(prefix-0xf7)
+		size = rlpDecodeSize(b[1:], headerSize-1)
+	}
+	if size < 0 || headerSize > len(b) || size > len(b)-headerSize {
+		return rlpItem{}, nil, errMalformedRLP
+	}
+
+	item.payload = b[headerSize : headerSize+size]
+	item.raw = b[:headerSize+size]
+	return item, b[headerSize+size:], nil
+}
+
+// rlpDecodeSize decodes a big endian size of the given number of bytes,
+// returning -1 if it is not available or too large.
+func rlpDecodeSize(b []byte, n int) int {
+	if n > len(b) || n > 4 {
+		return -1
+	}
+
+	var size int
+	for _, v := range b[:n] {
+		size = size<<8 | int(v)
+	}
+	return size
+}
+
+// rlpListItems decodes every item contained in the payload of a list.
+func rlpListItems(payload []byte) ([]rlpItem, error) {
+	var items []rlpItem
+	for len(payload) > 0 {
+		item, rest, err := rlpSplit(payload)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		payload = rest
+	}
+	return items, nil
+}
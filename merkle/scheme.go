@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Scheme determines how the hashes of leaf and interior nodes are computed.
+type Scheme uint8
+
+const (
+	// RawScheme hashes leaf values and the concatenation of child hashes
+	// as is. It is the default scheme but, since leaf and interior node
+	// hashes are indistinguishable from each other, it is vulnerable
+	// to second-preimage attacks where an interior node is presented
+	// as a leaf.
+	RawScheme Scheme = iota
+
+	// DomainSeparatedScheme prefixes leaf values with 0x00 and the
+	// concatenation of child hashes with 0x01 before hashing them,
+	// as described in RFC 6962.
+	DomainSeparatedScheme
+)
+
+// String implements the [fmt.Stringer] interface.
+func (s Scheme) String() string {
+	switch s {
+	case RawScheme:
+		return "raw"
+	case DomainSeparatedScheme:
+		return "domain_separated"
+	default:
+		return fmt.Sprintf("Scheme(%d)", uint8(s))
+	}
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (s Scheme) MarshalText() ([]byte, error) {
+	switch s {
+	case RawScheme, DomainSeparatedScheme:
+		return []byte(s.String()), nil
+	default:
+		return nil, fmt.Errorf("unknown scheme: %d", uint8(s))
+	}
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (s *Scheme) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "raw":
+		*s = RawScheme
+	case "domain_separated":
+		*s = DomainSeparatedScheme
+	default:
+		return fmt.Errorf("unknown scheme: %q", b)
+	}
+	return nil
+}
+
+const (
+	leafHashPrefix = 0x00
+	nodeHashPrefix = 0x01
+)
+
+func (s Scheme) hashLeaf(hasher hash.Hash, leaf io.Reader) ([]byte, error) {
+	if s == DomainSeparatedScheme {
+		leaf = io.MultiReader(bytes.NewReader([]byte{leafHashPrefix}), leaf)
+	}
+	return hashAll(hasher, leaf)
+}
+
+// hashChildren hashes the concatenation of the given child hashes, which are
+// usually a left and right child but can be more for an [NaryTree].
+func (s Scheme) hashChildren(hasher hash.Hash, children ...[]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if s == DomainSeparatedScheme {
+		buf.WriteByte(nodeHashPrefix)
+	}
+	for _, child := range children {
+		buf.Write(child)
+	}
+
+	return hashAll(hasher, &buf)
+}
+
+func hashAll(hasher hash.Hash, r io.Reader) ([]byte, error) {
+	// ensure state independent hashes aka each node hash is reproducible
+	// and independent of the hashing operations that came before it
+	hasher.Reset()
+
+	_, err := io.Copy(hasher, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// WithScheme sets the [Scheme] used for hashing leaf and interior nodes.
+// The same scheme must be used when verifying proofs as was used when
+// constructing the tree.
+func WithScheme(s Scheme) Option {
+	return func(o *options) {
+		o.scheme = s
+	}
+}
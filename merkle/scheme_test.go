@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainSeparatedScheme(t *testing.T) {
+	t.Run("will prefix leaf values with 0x00", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), []*strings.Reader{strings.NewReader("a")}, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		expected := sha256.Sum256([]byte("\x00a"))
+		require.Equal(t, expected[:], tree.Hash())
+	})
+
+	t.Run("will prefix child hashes with 0x01", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(2), WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		var buf bytes.Buffer
+		buf.WriteByte(0x01)
+		buf.Write(tree.Left().Hash())
+		buf.Write(tree.Right().Hash())
+
+		expected := sha256.Sum256(buf.Bytes())
+		require.Equal(t, expected[:], tree.Hash())
+	})
+
+	t.Run("will not allow an interior node to be presented as a leaf", func(t *testing.T) {
+		for _, scheme := range []Scheme{RawScheme, DomainSeparatedScheme} {
+			tree, err := NewBinaryTree(sha256.New(), leafValues(2), WithScheme(scheme))
+			require.Nil(t, err)
+
+			var forged bytes.Buffer
+			if scheme == DomainSeparatedScheme {
+				forged.WriteByte(0x01)
+			}
+			forged.Write(tree.Left().Hash())
+			forged.Write(tree.Right().Hash())
+
+			forgedTree, err := NewBinaryTree(sha256.New(), []*bytes.Buffer{&forged}, WithScheme(scheme))
+			require.Nil(t, err)
+
+			if scheme == RawScheme {
+				require.Equal(t, tree.String(), forgedTree.String())
+				continue
+			}
+			require.NotEqual(t, tree.String(), forgedTree.String())
+		}
+	})
+
+	t.Run("will produce proofs which only verify with the same scheme", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(5), WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		proof, err := tree.Prove(3)
+		require.Nil(t, err)
+
+		err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("3"), proof, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+
+		err = VerifyInclusion(sha256.New(), tree.Hash(), strings.NewReader("3"), proof)
+		require.ErrorIs(t, err, ErrRootMismatch)
+	})
+}
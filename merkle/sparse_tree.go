@@ -0,0 +1,270 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"hash"
+)
+
+// SparseTree is a sparse merkle tree which maps keys to values. Every possible
+// key has a leaf in the tree, located at the path given by the hash of the key,
+// so a tree built with a 256-bit hash function has 2^256 leaves. Since almost
+// every leaf is empty, only the hashes of subtrees which contain at least one
+// value are stored, all other subtrees have a precomputed default hash.
+//
+// Unlike a [BinaryTree], a SparseTree can prove that a key is not contained
+// in it. A SparseTree is not safe for concurrent use.
+type SparseTree struct {
+	hasher   hash.Hash
+	depth    int
+	defaults [][]byte
+
+	values map[string][]byte
+	nodes  map[sparseNodeID][]byte
+}
+
+// sparseNodeID identifies a node by its height above the leaves
+// and the path to it, with every bit below its height cleared.
+type sparseNodeID struct {
+	height int
+	prefix string
+}
+
+// SparseProof proves that a key either has a specific value in a [SparseTree]
+// or is not contained in it at all.
+type SparseProof struct {
+	// Bitmap has the i-th bit set if the sibling at height i, counting up from
+	// the leaf, is not the root of an empty subtree.
+	Bitmap []byte
+
+	// Siblings contains the hashes of every non-empty sibling, ordered from the
+	// leaf up to the root. Empty siblings are omitted since they can be recomputed
+	// by the verifier.
+	Siblings [][]byte
+}
+
+// NewSparseTree returns an empty [SparseTree] which uses the given hasher to hash
+// keys, leaves and interior nodes. The height of the tree is the number of bits
+// in the hashes produced by the hasher.
+func NewSparseTree(hasher hash.Hash) *SparseTree {
+	depth := hasher.Size() * 8
+
+	return &SparseTree{
+		hasher:   hasher,
+		depth:    depth,
+		defaults: sparseDefaults(hasher, depth),
+		values:   make(map[string][]byte),
+		nodes:    make(map[sparseNodeID][]byte),
+	}
+}
+
+// sparseDefaults returns the hash of an empty subtree for every height
+// from the leaves, at index 0, up to the root.
+func sparseDefaults(hasher hash.Hash, depth int) [][]byte {
+	defaults := make([][]byte, depth+1)
+	defaults[0] = EmptyTreeHash(hasher)
+	for h := 1; h <= depth; h++ {
+		defaults[h] = sparseHashChildren(hasher, defaults[h-1], defaults[h-1])
+	}
+	return defaults
+}
+
+// Root returns the root hash of the tree.
+func (t *SparseTree) Root() []byte {
+	return t.node(sparseNodeID{height: t.depth, prefix: string(make([]byte, t.depth/8))})
+}
+
+// Get returns the value for the given key and true, if the
+// key is contained in the tree, otherwise it returns false.
+func (t *SparseTree) Get(key []byte) ([]byte, bool) {
+	v, ok := t.values[string(t.path(key))]
+	return v, ok
+}
+
+// Set maps the given key to the given value, replacing any previous value.
+func (t *SparseTree) Set(key, value []byte) {
+	path := t.path(key)
+	value = bytes.Clone(value)
+	if value == nil {
+		value = []byte{}
+	}
+
+	t.values[string(path)] = value
+	t.update(path, sparseHashLeaf(t.hasher, value))
+}
+
+// Delete removes the given key from the tree, if it is contained in it.
+func (t *SparseTree) Delete(key []byte) {
+	path := t.path(key)
+	if _, ok := t.values[string(path)]; !ok {
+		return
+	}
+
+	delete(t.values, string(path))
+	t.update(path, t.defaults[0])
+}
+
+// Prove returns a [SparseProof] for the given key. If the key is contained in the
+// tree, the proof can be checked with [VerifySparseMembership], otherwise it can be
+// checked with [VerifySparseNonMembership].
+func (t *SparseTree) Prove(key []byte) *SparseProof {
+	path := t.path(key)
+
+	proof := &SparseProof{
+		Bitmap: make([]byte, (t.depth+7)/8),
+	}
+	for h := range t.depth {
+		sibling := sparseNodeID{
+			height: h,
+			prefix: string(sparsePrefix(flipBit(path, t.depth-1-h), h)),
+		}
+
+		hash, ok := t.nodes[sibling]
+		if !ok {
+			continue
+		}
+		proof.Bitmap[h/8] |= 1 << (h % 8)
+		proof.Siblings = append(proof.Siblings, hash)
+	}
+	return proof
+}
+
+func (t *SparseTree) path(key []byte) []byte {
+	t.hasher.Reset()
+	t.hasher.Write(key)
+	return t.hasher.Sum(nil)
+}
+
+func (t *SparseTree) node(id sparseNodeID) []byte {
+	hash, ok := t.nodes[id]
+	if ok {
+		return hash
+	}
+	return t.defaults[id.height]
+}
+
+// update sets the leaf at the given path to the given hash
+// and recomputes every node on the path up to the root.
+func (t *SparseTree) update(path, hash []byte) {
+	for h := 0; h <= t.depth; h++ {
+		id := sparseNodeID{
+			height: h,
+			prefix: string(sparsePrefix(path, h)),
+		}
+		if bytes.Equal(hash, t.defaults[h]) {
+			delete(t.nodes, id)
+		} else {
+			t.nodes[id] = hash
+		}
+		if h == t.depth {
+			return
+		}
+
+		bit := t.depth - 1 - h
+		sibling := t.node(sparseNodeID{
+			height: h,
+			prefix: string(sparsePrefix(flipBit(path, bit), h)),
+		})
+		if getBit(path, bit) {
+			hash = sparseHashChildren(t.hasher, sibling, hash)
+		} else {
+			hash = sparseHashChildren(t.hasher, hash, sibling)
+		}
+	}
+}
+
+// VerifySparseMembership verifies that the given key maps to the given value in
+// the [SparseTree] with the given root hash. The hasher must be the same hash
+// function used by the tree. A nil error is only returned if the proof is valid.
+func VerifySparseMembership(hasher hash.Hash, root, key, value []byte, proof *SparseProof) error {
+	return verifySparseProof(hasher, root, key, sparseHashLeaf(hasher, value), proof)
+}
+
+// VerifySparseNonMembership verifies that the given key is not contained in the
+// [SparseTree] with the given root hash. The hasher must be the same hash function
+// used by the tree. A nil error is only returned if the proof is valid.
+func VerifySparseNonMembership(hasher hash.Hash, root, key []byte, proof *SparseProof) error {
+	return verifySparseProof(hasher, root, key, EmptyTreeHash(hasher), proof)
+}
+
+func verifySparseProof(hasher hash.Hash, root, key, leaf []byte, proof *SparseProof) error {
+	depth := hasher.Size() * 8
+	if proof == nil || len(proof.Bitmap) != (depth+7)/8 {
+		return ErrInvalidProof
+	}
+
+	hasher.Reset()
+	hasher.Write(key)
+	path := hasher.Sum(nil)
+
+	defaults := sparseDefaults(hasher, depth)
+	siblings := proof.Siblings
+
+	hash := leaf
+	for h := range depth {
+		sibling := defaults[h]
+		if proof.Bitmap[h/8]&(1<<(h%8)) != 0 {
+			if len(siblings) == 0 {
+				return ErrInvalidProof
+			}
+			sibling = siblings[0]
+			siblings = siblings[1:]
+		}
+
+		if getBit(path, depth-1-h) {
+			hash = sparseHashChildren(hasher, sibling, hash)
+		} else {
+			hash = sparseHashChildren(hasher, hash, sibling)
+		}
+	}
+	if len(siblings) != 0 {
+		return ErrInvalidProof
+	}
+
+	if !bytes.Equal(hash, root) {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+func sparseHashLeaf(hasher hash.Hash, value []byte) []byte {
+	hasher.Reset()
+	hasher.Write([]byte{leafHashPrefix})
+	hasher.Write(value)
+	return hasher.Sum(nil)
+}
+
+func sparseHashChildren(hasher hash.Hash, left, right []byte) []byte {
+	hasher.Reset()
+	hasher.Write([]byte{nodeHashPrefix})
+	hasher.Write(left)
+	hasher.Write(right)
+	return hasher.Sum(nil)
+}
+
+// getBit reports whether the i-th most significant bit of b is set.
+func getBit(b []byte, i int) bool {
+	return b[i/8]&(0x80>>(i%8)) != 0
+}
+
+// flipBit returns a copy of b with the i-th most significant bit flipped.
+func flipBit(b []byte, i int) []byte {
+	b = bytes.Clone(b)
+	b[i/8] ^= 0x80 >> (i % 8)
+	return b
+}
+
+// sparsePrefix returns a copy of the path with its height least
+// significant bits cleared.
+func sparsePrefix(path []byte, height int) []byte {
+	prefix := bytes.Clone(path)
+	bits := len(path) * 8
+	for i := bits - height; i < bits; i++ {
+		prefix[i/8] &^= 0x80 >> (i % 8)
+	}
+	return prefix
+}
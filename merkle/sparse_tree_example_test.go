@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+func ExampleSparseTree() {
+	tree := NewSparseTree(sha256.New())
+	tree.Set([]byte("alice"), []byte("100"))
+	tree.Set([]byte("bob"), []byte("50"))
+	root := tree.Root()
+
+	proof := tree.Prove([]byte("alice"))
+	err := VerifySparseMembership(sha256.New(), root, []byte("alice"), []byte("100"), proof)
+	fmt.Println(err)
+
+	proof = tree.Prove([]byte("carol"))
+	err = VerifySparseNonMembership(sha256.New(), root, []byte("carol"), proof)
+	fmt.Println(err)
+
+	// Output: <nil>
+	// <nil>
+}
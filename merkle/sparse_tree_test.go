@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseTree_Set(t *testing.T) {
+	t.Run("will make the value retrievable by its key", func(t *testing.T) {
+		tree := NewSparseTree(sha256.New())
+		tree.Set([]byte("a"), []byte("1"))
+		tree.Set([]byte("b"), nil)
+
+		v, ok := tree.Get([]byte("a"))
+		require.True(t, ok)
+		require.Equal(t, []byte("1"), v)
+
+		v, ok = tree.Get([]byte("b"))
+		require.True(t, ok)
+		require.Empty(t, v)
+
+		_, ok = tree.Get([]byte("c"))
+		require.False(t, ok)
+	})
+
+	t.Run("will change the root", func(t *testing.T) {
+		tree := NewSparseTree(sha256.New())
+		empty := tree.Root()
+
+		tree.Set([]byte("a"), []byte("1"))
+		first := tree.Root()
+		require.NotEqual(t, empty, first)
+
+		tree.Set([]byte("a"), []byte("2"))
+		require.NotEqual(t, first, tree.Root())
+	})
+
+	t.Run("will produce the same root regardless of insertion order", func(t *testing.T) {
+		a := NewSparseTree(sha256.New())
+		b := NewSparseTree(sha256.New())
+		for i := range 20 {
+			a.Set([]byte(strconv.Itoa(i)), []byte("value"+strconv.Itoa(i)))
+			b.Set([]byte(strconv.Itoa(19-i)), []byte("value"+strconv.Itoa(19-i)))
+		}
+
+		require.Equal(t, a.Root(), b.Root())
+	})
+}
+
+func TestSparseTree_Delete(t *testing.T) {
+	t.Run("will restore the previous root", func(t *testing.T) {
+		tree := NewSparseTree(sha256.New())
+		empty := tree.Root()
+
+		tree.Set([]byte("a"), []byte("1"))
+		withA := tree.Root()
+
+		tree.Set([]byte("b"), []byte("2"))
+		tree.Delete([]byte("b"))
+		require.Equal(t, withA, tree.Root())
+
+		_, ok := tree.Get([]byte("b"))
+		require.False(t, ok)
+
+		tree.Delete([]byte("a"))
+		require.Equal(t, empty, tree.Root())
+		require.Empty(t, tree.nodes)
+	})
+
+	t.Run("will do nothing if the key is not contained in the tree", func(t *testing.T) {
+		tree := NewSparseTree(sha256.New())
+		tree.Set([]byte("a"), []byte("1"))
+		root := tree.Root()
+
+		tree.Delete([]byte("b"))
+		require.Equal(t, root, tree.Root())
+	})
+}
+
+func TestSparseTree_Prove(t *testing.T) {
+	tree := NewSparseTree(sha256.New())
+	for i := range 50 {
+		tree.Set([]byte(strconv.Itoa(i)), []byte("value"+strconv.Itoa(i)))
+	}
+	root := tree.Root()
+
+	t.Run("will return a compact proof", func(t *testing.T) {
+		proof := tree.Prove([]byte("1"))
+		require.Len(t, proof.Bitmap, 32)
+		require.Less(t, len(proof.Siblings), 16)
+	})
+
+	t.Run("will prove membership of every key", func(t *testing.T) {
+		for i := range 50 {
+			key := []byte(strconv.Itoa(i))
+
+			proof := tree.Prove(key)
+			err := VerifySparseMembership(sha256.New(), root, key, []byte("value"+strconv.Itoa(i)), proof)
+			require.Nil(t, err)
+
+			err = VerifySparseNonMembership(sha256.New(), root, key, proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		}
+	})
+
+	t.Run("will prove non-membership of missing keys", func(t *testing.T) {
+		for i := 50; i < 100; i++ {
+			key := []byte(strconv.Itoa(i))
+
+			proof := tree.Prove(key)
+			err := VerifySparseNonMembership(sha256.New(), root, key, proof)
+			require.Nil(t, err)
+
+			err = VerifySparseMembership(sha256.New(), root, key, nil, proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		}
+	})
+
+	t.Run("will prove non-membership in an empty tree", func(t *testing.T) {
+		empty := NewSparseTree(sha256.New())
+
+		proof := empty.Prove([]byte("a"))
+		require.Empty(t, proof.Siblings)
+
+		err := VerifySparseNonMembership(sha256.New(), empty.Root(), []byte("a"), proof)
+		require.Nil(t, err)
+	})
+
+	t.Run("will fail to verify", func(t *testing.T) {
+		t.Run("if the value is different", func(t *testing.T) {
+			proof := tree.Prove([]byte("1"))
+			err := VerifySparseMembership(sha256.New(), root, []byte("1"), []byte("value2"), proof)
+			require.ErrorIs(t, err, ErrRootMismatch)
+		})
+
+		t.Run("if the proof is for a different key", func(t *testing.T) {
+			proof := tree.Prove([]byte("1"))
+			err := VerifySparseMembership(sha256.New(), root, []byte("2"), []byte("value2"), proof)
+			require.Error(t, err)
+		})
+
+		t.Run("if the proof is nil", func(t *testing.T) {
+			err := VerifySparseNonMembership(sha256.New(), root, []byte("1"), nil)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the bitmap does not match the number of siblings", func(t *testing.T) {
+			proof := tree.Prove([]byte("1"))
+			proof.Bitmap = bytes.Clone(proof.Bitmap)
+			proof.Bitmap[31] ^= 0x80
+
+			err := VerifySparseMembership(sha256.New(), root, []byte("1"), []byte("value1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+
+		t.Run("if the bitmap has the wrong length", func(t *testing.T) {
+			proof := tree.Prove([]byte("1"))
+			proof.Bitmap = proof.Bitmap[:31]
+
+			err := VerifySparseMembership(sha256.New(), root, []byte("1"), []byte("value1"), proof)
+			require.ErrorIs(t, err, ErrInvalidProof)
+		})
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"hash"
+	"io"
+	"math/bits"
+)
+
+// StoredTree is an append-only merkle tree whose nodes live in a [NodeStore]
+// instead of memory, which allows it to grow larger than the available memory.
+// It has the same shape and root hash as a [BinaryTree] constructed from the same
+// leaves and options.
+//
+// Only the hashes of complete subtrees are stored, in post-order, so appending a
+// leaf never rewrites an existing node. The hashes of incomplete subtrees are
+// recomputed from the store whenever they are needed. A StoredTree is not safe
+// for concurrent use.
+type StoredTree struct {
+	hasher hash.Hash
+	scheme Scheme
+	store  NodeStore
+	size   int
+
+	// frontier holds the hashes needed to append a leaf, which
+	// are the complete subtrees with no parent in the store.
+	frontier frontier
+}
+
+// NewStoredTree returns a [StoredTree] whose nodes are stored in the given store.
+// If the store already contains nodes, e.g. from a previous process, the tree
+// continues where it left off. The hasher and options must be the same as those
+// used when the existing nodes were appended.
+func NewStoredTree(hasher hash.Hash, store NodeStore, opts ...Option) (*StoredTree, error) {
+	nodes, err := store.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	// complete subtrees are laid out exactly like the mountains of a mountain range
+	size, ok := mountainRangeLeaves(nodes)
+	if !ok {
+		return nil, ErrInvalidNodeStore
+	}
+
+	frontier, err := loadFrontier(store, size)
+	if err != nil {
+		return nil, err
+	}
+
+	o := applyOptions(opts)
+
+	return &StoredTree{
+		hasher:   hasher,
+		scheme:   o.scheme,
+		store:    store,
+		size:     int(size),
+		frontier: frontier,
+	}, nil
+}
+
+// Size returns the number of leaves which have been appended.
+func (t *StoredTree) Size() int {
+	return t.size
+}
+
+// Append hashes the given leaf and appends it, along with every complete
+// subtree it completes, to the store.
+func (t *StoredTree) Append(leaf io.Reader) error {
+	hash, err := t.scheme.hashLeaf(t.hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	frontier, completed, err := t.frontier.append(t.hasher, t.scheme, hash)
+	if err != nil {
+		return err
+	}
+
+	err = t.store.Append(completed...)
+	if err != nil {
+		return err
+	}
+
+	t.frontier = frontier
+	t.size += 1
+	return nil
+}
+
+// Root returns the root hash of the tree containing every appended leaf.
+func (t *StoredTree) Root() ([]byte, error) {
+	return t.RootAt(t.size)
+}
+
+// RootAt returns the root hash the tree had when it contained the given number of leaves.
+func (t *StoredTree) RootAt(size int) ([]byte, error) {
+	if size == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+	if size < 0 || size > t.size {
+		return nil, ErrInvalidTreeSize
+	}
+	return t.rangeHash(0, size)
+}
+
+// Prove returns an [InclusionProof] for the leaf at the given index in
+// the tree as it was when it contained treeSize leaves.
+func (t *StoredTree) Prove(leafIndex, treeSize int) (*InclusionProof, error) {
+	if treeSize <= 0 || treeSize > t.size {
+		return nil, ErrInvalidTreeSize
+	}
+	if leafIndex < 0 || leafIndex >= treeSize {
+		return nil, ErrLeafIndexOutOfRange
+	}
+	return proveInclusion(t.rangeHash, leafIndex, treeSize)
+}
+
+// ProveConsistency returns a consistency proof between the tree as it was when
+// it contained oldSize leaves and the tree as it was when it contained newSize leaves.
+func (t *StoredTree) ProveConsistency(oldSize, newSize int) ([][]byte, error) {
+	if newSize < 0 || newSize > t.size || oldSize < 0 || oldSize > newSize {
+		return nil, ErrInvalidTreeSize
+	}
+	return proveConsistency(t.rangeHash, oldSize, newSize)
+}
+
+// RootNode returns the root of the tree containing every appended leaf, which can
+// be used to lazily traverse the tree. It returns nil if no leaf has been appended.
+func (t *StoredTree) RootNode() *StoredNode {
+	if t.size == 0 {
+		return nil
+	}
+	return &StoredNode{
+		tree: t,
+		size: t.size,
+	}
+}
+
+// rangeHash returns the hash of the subtree over the leaves [start, start+n).
+func (t *StoredTree) rangeHash(start, n int) ([]byte, error) {
+	if n&(n-1) == 0 && start%n == 0 {
+		height := bits.TrailingZeros(uint(n))
+		return t.store.Node(mountainRangeLeafPos(uint64(start)) + 2<<height - 2)
+	}
+
+	k := splitPoint(n)
+	left, err := t.rangeHash(start, k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := t.rangeHash(start+k, n-k)
+	if err != nil {
+		return nil, err
+	}
+	return t.scheme.hashChildren(t.hasher, left, right)
+}
+
+// StoredNode is a node of a [StoredTree]. Its hash and children are only
+// loaded from the store when they are requested.
+type StoredNode struct {
+	tree *StoredTree
+
+	// start is the index of the first leaf contained in this node.
+	start int
+
+	// size is the number of leaves contained in this node.
+	size int
+}
+
+// Hash returns the raw hash value for this node.
+func (n *StoredNode) Hash() ([]byte, error) {
+	return n.tree.rangeHash(n.start, n.size)
+}
+
+// Size returns the number of leaves contained in this node.
+func (n *StoredNode) Size() int {
+	return n.size
+}
+
+// Left returns the left child node. Note, this will be nil if [IsLeaf] returns true.
+func (n *StoredNode) Left() *StoredNode {
+	if n.IsLeaf() {
+		return nil
+	}
+	return &StoredNode{
+		tree:  n.tree,
+		start: n.start,
+		size:  splitPoint(n.size),
+	}
+}
+
+// Right returns the right child node. Note, this will be nil if [IsLeaf] returns true.
+func (n *StoredNode) Right() *StoredNode {
+	if n.IsLeaf() {
+		return nil
+	}
+
+	k := splitPoint(n.size)
+	return &StoredNode{
+		tree:  n.tree,
+		start: n.start + k,
+		size:  n.size - k,
+	}
+}
+
+// IsLeaf reports whether this node represents a leaf value.
+func (n *StoredNode) IsLeaf() bool {
+	return n.size == 1
+}
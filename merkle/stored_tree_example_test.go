@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func ExampleStoredTree() {
+	dir, err := os.MkdirTemp("", "merkle")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := OpenFileNodeStore(filepath.Join(dir, "nodes"), sha256.Size)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer store.Close()
+
+	tree, err := NewStoredTree(sha256.New(), store)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, leaf := range []string{"a", "b", "c"} {
+		err := tree.Append(strings.NewReader(leaf))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	root, err := tree.Root()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%x\n", root)
+	// Output: 7075152d03a5cd92104887b476862778ec0c87be5c2fa1c0a90f87c49fad6eff
+}
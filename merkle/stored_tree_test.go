@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildStoredTree(t *testing.T, store NodeStore, n int) *StoredTree {
+	tree, err := NewStoredTree(sha256.New(), store, WithScheme(RFC6962Scheme))
+	require.Nil(t, err)
+
+	for _, leaf := range leafValues(n) {
+		err := tree.Append(leaf)
+		require.Nil(t, err)
+	}
+	return tree
+}
+
+func TestStoredTree(t *testing.T) {
+	stored := buildStoredTree(t, NewMemoryNodeStore(), 37)
+
+	t.Run("will match a BinaryTree at every size", func(t *testing.T) {
+		for size := 1; size <= stored.Size(); size++ {
+			tree, err := NewBinaryTree(sha256.New(), leafValues(size), WithScheme(RFC6962Scheme))
+			require.Nil(t, err)
+
+			root, err := stored.RootAt(size)
+			require.Nil(t, err)
+			require.Equal(t, tree.Hash(), root)
+
+			for i := range size {
+				expected, err := tree.Prove(i)
+				require.Nil(t, err)
+
+				proof, err := stored.Prove(i, size)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+
+			for oldSize := 0; oldSize <= size; oldSize++ {
+				expected, err := tree.ProveConsistency(oldSize)
+				require.Nil(t, err)
+
+				proof, err := stored.ProveConsistency(oldSize, size)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+		}
+	})
+
+	t.Run("will lazily traverse the same nodes as a BinaryTree", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(stored.Size()), WithScheme(RFC6962Scheme))
+		require.Nil(t, err)
+
+		var walk func(t *testing.T, expected *BinaryTree, node *StoredNode)
+		walk = func(t *testing.T, expected *BinaryTree, node *StoredNode) {
+			hash, err := node.Hash()
+			require.Nil(t, err)
+			require.Equal(t, expected.Hash(), hash)
+			require.Equal(t, expected.IsLeaf(), node.IsLeaf())
+
+			if node.IsLeaf() {
+				require.Nil(t, node.Left())
+				require.Nil(t, node.Right())
+				return
+			}
+			walk(t, expected.Left(), node.Left())
+			walk(t, expected.Right(), node.Right())
+		}
+		walk(t, tree, stored.RootNode())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the tree is empty", func(t *testing.T) {
+			empty := buildStoredTree(t, NewMemoryNodeStore(), 0)
+			require.Nil(t, empty.RootNode())
+
+			_, err := empty.Root()
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if the size is larger than the tree", func(t *testing.T) {
+			_, err := stored.RootAt(38)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+
+			_, err = stored.Prove(0, 38)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+
+			_, err = stored.ProveConsistency(1, 38)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+		})
+
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			_, err := stored.Prove(10, 10)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+
+		t.Run("if the store does not contain a valid tree", func(t *testing.T) {
+			store := NewMemoryNodeStore()
+			err := store.Append(make([]byte, 32), make([]byte, 32))
+			require.Nil(t, err)
+
+			_, err = NewStoredTree(sha256.New(), store)
+			require.ErrorIs(t, err, ErrInvalidNodeStore)
+		})
+	})
+}
+
+func TestStoredTree_FileNodeStore(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "nodes")
+
+	t.Run("will continue where a previous tree left off", func(t *testing.T) {
+		store, err := OpenFileNodeStore(name, sha256.Size)
+		require.Nil(t, err)
+
+		tree := buildStoredTree(t, store, 20)
+		root, err := tree.Root()
+		require.Nil(t, err)
+		require.Nil(t, store.Close())
+
+		store, err = OpenFileNodeStore(name, sha256.Size)
+		require.Nil(t, err)
+		defer store.Close()
+
+		resumed, err := NewStoredTree(sha256.New(), store, WithScheme(RFC6962Scheme))
+		require.Nil(t, err)
+		require.Equal(t, 20, resumed.Size())
+
+		resumedRoot, err := resumed.Root()
+		require.Nil(t, err)
+		require.Equal(t, root, resumedRoot)
+
+		expected, err := NewBinaryTree(sha256.New(), leafValues(25), WithScheme(RFC6962Scheme))
+		require.Nil(t, err)
+
+		for _, leaf := range leafValues(25)[20:] {
+			err := resumed.Append(leaf)
+			require.Nil(t, err)
+		}
+
+		resumedRoot, err = resumed.Root()
+		require.Nil(t, err)
+		require.Equal(t, expected.Hash(), resumedRoot)
+	})
+}
@@ -0,0 +1,406 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"slices"
+)
+
+// LeafRange is the half open range [Start, End) of leaf indices.
+type LeafRange struct {
+	Start int
+	End   int
+}
+
+var (
+	// ErrSyncProtocolViolation is returned if a peer sends an unexpected
+	// or malformed message during [Sync] or [ServeSync].
+	ErrSyncProtocolViolation = errors.New("sync protocol violation")
+
+	// ErrIncompatibleTrees is returned by [Sync] and [ServeSync] if the trees
+	// of the peers were constructed with different schemes or hash sizes.
+	ErrIncompatibleTrees = errors.New("trees are not compatible")
+)
+
+const syncProtocolVersion = 1
+
+const (
+	syncHelloMsg  byte = 'H'
+	syncQueryMsg  byte = 'Q'
+	syncAnswerMsg byte = 'A'
+	syncDoneMsg   byte = 'D'
+)
+
+// maxSyncHashSize bounds the size of hashes accepted from a peer.
+const maxSyncHashSize = 1024
+
+// Sync initiates the anti-entropy protocol with a peer, which must be running
+// [ServeSync], over the given connection. It returns the ranges of leaves which
+// differ between the two trees, or are only contained in one of them.
+//
+// The peers exchange the hashes of all subtrees at the same level of the tree in
+// a single round trip and only descend into subtrees whose hashes differ. This
+// means the number of round trips is bounded by the height of the tree. Once the
+// differing ranges have been found they are also sent to the peer.
+//
+// If an error is returned the connection should be closed, since the peer may
+// still be waiting for a message.
+func Sync(rw io.ReadWriter, tree *BinaryTree) ([]LeafRange, error) {
+	conn := newSyncConn(rw)
+
+	err := conn.writeHello(tree)
+	if err != nil {
+		return nil, err
+	}
+	peer, err := conn.readHello(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatched []LeafRange
+	common := min(tree.size, peer.size)
+	if tree.size == peer.size && bytes.Equal(tree.hash, peer.root) {
+		return nil, conn.writeDone(mismatched)
+	}
+
+	type offsetNode struct {
+		node  *BinaryTree
+		start int
+	}
+
+	level := []offsetNode{{node: tree}}
+	for len(level) > 0 {
+		var query []offsetNode
+		for len(level) > 0 {
+			n := level[0]
+			level = level[1:]
+
+			end := n.start + n.node.size
+			switch {
+			case n.start >= common:
+				mismatched = append(mismatched, LeafRange{Start: n.start, End: end})
+			case end > common:
+				// the peer can not have a node which covers leaves it does not have
+				level = append(level,
+					offsetNode{node: n.node.left, start: n.start},
+					offsetNode{node: n.node.right, start: n.start + n.node.left.size},
+				)
+			default:
+				query = append(query, n)
+			}
+		}
+		if len(query) == 0 {
+			break
+		}
+
+		ranges := make([]LeafRange, len(query))
+		for i, q := range query {
+			ranges[i] = LeafRange{Start: q.start, End: q.start + q.node.size}
+		}
+		err := conn.writeQuery(ranges)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := conn.readAnswer(len(query), len(tree.hash))
+		if err != nil {
+			return nil, err
+		}
+
+		for i, q := range query {
+			if hashes[i] != nil && bytes.Equal(hashes[i], q.node.hash) {
+				continue
+			}
+			if q.node.IsLeaf() {
+				mismatched = append(mismatched, ranges[i])
+				continue
+			}
+			level = append(level,
+				offsetNode{node: q.node.left, start: q.start},
+				offsetNode{node: q.node.right, start: q.start + q.node.left.size},
+			)
+		}
+	}
+	if peer.size > tree.size {
+		mismatched = append(mismatched, LeafRange{Start: tree.size, End: peer.size})
+	}
+
+	mismatched = mergeLeafRanges(mismatched)
+	return mismatched, conn.writeDone(mismatched)
+}
+
+// ServeSync responds to the anti-entropy protocol initiated by a peer calling
+// [Sync] over the given connection. It returns the ranges of leaves which differ
+// between the two trees, as determined by the peer.
+func ServeSync(rw io.ReadWriter, tree *BinaryTree) ([]LeafRange, error) {
+	conn := newSyncConn(rw)
+
+	peer, err := conn.readHello(tree)
+	if err != nil {
+		return nil, err
+	}
+	err = conn.writeHello(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := max(tree.size, peer.size)
+	for {
+		msgType, err := conn.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch msgType {
+		case syncQueryMsg:
+			ranges, err := conn.readRanges(2*tree.size, tree.size)
+			if err != nil {
+				return nil, err
+			}
+
+			hashes := make([][]byte, len(ranges))
+			for i, r := range ranges {
+				node := tree.subtree(r.Start, r.End-r.Start)
+				if node != nil {
+					hashes[i] = node.hash
+				}
+			}
+
+			err = conn.writeAnswer(hashes)
+			if err != nil {
+				return nil, err
+			}
+		case syncDoneMsg:
+			return conn.readRanges(maxSize, maxSize)
+		default:
+			return nil, ErrSyncProtocolViolation
+		}
+	}
+}
+
+// subtree returns the node which covers exactly the leaves [start, start+n)
+// or nil, if there is no such node.
+func (t *BinaryTree) subtree(start, n int) *BinaryTree {
+	node := t
+	for node != nil {
+		switch {
+		case start == 0 && n == node.size:
+			return node
+		case node.IsLeaf():
+			return nil
+		case start+n <= node.left.size:
+			node = node.left
+		case start >= node.left.size:
+			start -= node.left.size
+			node = node.right
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func mergeLeafRanges(ranges []LeafRange) []LeafRange {
+	slices.SortFunc(ranges, func(a, b LeafRange) int {
+		return a.Start - b.Start
+	})
+
+	var merged []LeafRange
+	for _, r := range ranges {
+		if len(merged) > 0 && merged[len(merged)-1].End >= r.Start {
+			merged[len(merged)-1].End = max(merged[len(merged)-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+type syncConn struct {
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+func newSyncConn(rw io.ReadWriter) *syncConn {
+	return &syncConn{
+		r: bufio.NewReader(rw),
+		w: bufio.NewWriter(rw),
+	}
+}
+
+type syncHello struct {
+	size int
+	root []byte
+}
+
+func (c *syncConn) writeHello(tree *BinaryTree) error {
+	b := []byte{syncHelloMsg, syncProtocolVersion, byte(tree.scheme)}
+	b = binary.AppendUvarint(b, uint64(tree.size))
+	b = binary.AppendUvarint(b, uint64(len(tree.hash)))
+	b = append(b, tree.hash...)
+	return c.write(b)
+}
+
+func (c *syncConn) readHello(tree *BinaryTree) (*syncHello, error) {
+	var header [3]byte
+	_, err := io.ReadFull(c.r, header[:])
+	if err != nil {
+		return nil, err
+	}
+	if header[0] != syncHelloMsg || header[1] != syncProtocolVersion {
+		return nil, ErrSyncProtocolViolation
+	}
+	if Scheme(header[2]) != tree.scheme {
+		return nil, ErrIncompatibleTrees
+	}
+
+	size, err := c.readInt()
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, ErrSyncProtocolViolation
+	}
+	hashSize, err := c.readInt()
+	if err != nil {
+		return nil, err
+	}
+	if hashSize != len(tree.hash) {
+		return nil, ErrIncompatibleTrees
+	}
+
+	root, err := c.readHash(hashSize)
+	if err != nil {
+		return nil, err
+	}
+	return &syncHello{size: size, root: root}, nil
+}
+
+func (c *syncConn) writeQuery(ranges []LeafRange) error {
+	return c.write(appendLeafRanges([]byte{syncQueryMsg}, ranges))
+}
+
+func (c *syncConn) writeDone(ranges []LeafRange) error {
+	return c.write(appendLeafRanges([]byte{syncDoneMsg}, ranges))
+}
+
+func appendLeafRanges(b []byte, ranges []LeafRange) []byte {
+	b = binary.AppendUvarint(b, uint64(len(ranges)))
+	for _, r := range ranges {
+		b = binary.AppendUvarint(b, uint64(r.Start))
+		b = binary.AppendUvarint(b, uint64(r.End-r.Start))
+	}
+	return b
+}
+
+// readRanges reads at most maxRanges non-empty ranges which
+// do not extend beyond maxEnd.
+func (c *syncConn) readRanges(maxRanges, maxEnd int) ([]LeafRange, error) {
+	count, err := c.readInt()
+	if err != nil {
+		return nil, err
+	}
+	if count > maxRanges {
+		return nil, ErrSyncProtocolViolation
+	}
+
+	ranges := make([]LeafRange, count)
+	for i := range ranges {
+		start, err := c.readInt()
+		if err != nil {
+			return nil, err
+		}
+		n, err := c.readInt()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 || start > maxEnd || n > maxEnd-start {
+			return nil, ErrSyncProtocolViolation
+		}
+		ranges[i] = LeafRange{Start: start, End: start + n}
+	}
+	return ranges, nil
+}
+
+func (c *syncConn) writeAnswer(hashes [][]byte) error {
+	b := []byte{syncAnswerMsg}
+	for _, hash := range hashes {
+		if hash == nil {
+			b = append(b, 0)
+			continue
+		}
+		b = append(b, 1)
+		b = append(b, hash...)
+	}
+	return c.write(b)
+}
+
+func (c *syncConn) readAnswer(count, hashSize int) ([][]byte, error) {
+	msgType, err := c.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if msgType != syncAnswerMsg {
+		return nil, ErrSyncProtocolViolation
+	}
+
+	hashes := make([][]byte, count)
+	for i := range hashes {
+		present, err := c.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch present {
+		case 0:
+		case 1:
+			hashes[i], err = c.readHash(hashSize)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrSyncProtocolViolation
+		}
+	}
+	return hashes, nil
+}
+
+func (c *syncConn) readInt() (int, error) {
+	v, err := binary.ReadUvarint(c.r)
+	if err != nil {
+		return 0, err
+	}
+	if v > math.MaxInt {
+		return 0, ErrSyncProtocolViolation
+	}
+	return int(v), nil
+}
+
+func (c *syncConn) readHash(hashSize int) ([]byte, error) {
+	if hashSize == 0 || hashSize > maxSyncHashSize {
+		return nil, ErrSyncProtocolViolation
+	}
+
+	hash := make([]byte, hashSize)
+	_, err := io.ReadFull(c.r, hash)
+	if err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+func (c *syncConn) write(b []byte) error {
+	_, err := c.w.Write(b)
+	if err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type syncResult struct {
+	ranges []LeafRange
+	err    error
+}
+
+func syncTrees(initiator, responder *BinaryTree) (syncResult, syncResult) {
+	a, b := net.Pipe()
+
+	served := make(chan syncResult, 1)
+	go func() {
+		defer b.Close()
+
+		ranges, err := ServeSync(b, responder)
+		served <- syncResult{ranges: ranges, err: err}
+	}()
+
+	ranges, err := Sync(a, initiator)
+	_ = a.Close()
+
+	return syncResult{ranges: ranges, err: err}, <-served
+}
+
+func diffRanges(a, b *BinaryTree) []LeafRange {
+	var ranges []LeafRange
+	for i := range Diff(a, b) {
+		if len(ranges) > 0 && ranges[len(ranges)-1].End == i {
+			ranges[len(ranges)-1].End += 1
+			continue
+		}
+		ranges = append(ranges, LeafRange{Start: i, End: i + 1})
+	}
+	return ranges
+}
+
+func TestSync(t *testing.T) {
+	construct := func(t *testing.T, numOfLeafs int, changed ...int) *BinaryTree {
+		leafs := leafValues(numOfLeafs)
+		for _, i := range changed {
+			leafs[i] = strings.NewReader("changed")
+		}
+
+		tree, err := NewBinaryTree(sha256.New(), leafs, WithScheme(DomainSeparatedScheme))
+		require.Nil(t, err)
+		return tree
+	}
+
+	t.Run("will not find any differences if the trees are identical", func(t *testing.T) {
+		initiator, responder := syncTrees(construct(t, 9), construct(t, 9))
+		require.Nil(t, initiator.err)
+		require.Nil(t, responder.err)
+		require.Empty(t, initiator.ranges)
+		require.Empty(t, responder.ranges)
+	})
+
+	t.Run("will find the same differences as Diff", func(t *testing.T) {
+		testCases := []struct {
+			Name      string
+			Initiator func(*testing.T) *BinaryTree
+			Responder func(*testing.T) *BinaryTree
+		}{
+			{
+				Name: "if a single leaf differs",
+				Initiator: func(t *testing.T) *BinaryTree {
+					return construct(t, 16)
+				},
+				Responder: func(t *testing.T) *BinaryTree {
+					return construct(t, 16, 7)
+				},
+			},
+			{
+				Name: "if multiple leaves differ",
+				Initiator: func(t *testing.T) *BinaryTree {
+					return construct(t, 21, 0, 1, 2, 10, 20)
+				},
+				Responder: func(t *testing.T) *BinaryTree {
+					return construct(t, 21, 3, 10)
+				},
+			},
+			{
+				Name: "if the initiator has more leaves",
+				Initiator: func(t *testing.T) *BinaryTree {
+					return construct(t, 13, 4)
+				},
+				Responder: func(t *testing.T) *BinaryTree {
+					return construct(t, 6)
+				},
+			},
+			{
+				Name: "if the responder has more leaves",
+				Initiator: func(t *testing.T) *BinaryTree {
+					return construct(t, 5)
+				},
+				Responder: func(t *testing.T) *BinaryTree {
+					return construct(t, 18, 1)
+				},
+			},
+			{
+				Name: "if every leaf differs",
+				Initiator: func(t *testing.T) *BinaryTree {
+					return construct(t, 3, 0, 1, 2)
+				},
+				Responder: func(t *testing.T) *BinaryTree {
+					return construct(t, 3)
+				},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				a := testCase.Initiator(t)
+				b := testCase.Responder(t)
+
+				initiator, responder := syncTrees(a, b)
+				require.Nil(t, initiator.err)
+				require.Nil(t, responder.err)
+				require.Equal(t, diffRanges(a, b), initiator.ranges)
+				require.Equal(t, initiator.ranges, responder.ranges)
+			})
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the trees use different schemes", func(t *testing.T) {
+			raw, err := ConstructBinaryTree(sha256.New(), leafValues(4)...)
+			require.Nil(t, err)
+
+			initiator, responder := syncTrees(raw, construct(t, 4))
+			require.Error(t, initiator.err)
+			require.ErrorIs(t, responder.err, ErrIncompatibleTrees)
+		})
+
+		t.Run("if the peer sends an unexpected message", func(t *testing.T) {
+			a, b := net.Pipe()
+			defer a.Close()
+
+			tree := construct(t, 4)
+			go func() {
+				defer b.Close()
+
+				conn := newSyncConn(b)
+				_, err := conn.readHello(tree)
+				if err != nil {
+					return
+				}
+				_ = conn.writeHello(tree)
+				_, _ = conn.r.ReadByte()
+				_, _ = conn.readRanges(8, 4)
+				_ = conn.write([]byte{'X'})
+			}()
+
+			ranges, err := Sync(a, construct(t, 4, 1))
+			require.ErrorIs(t, err, ErrSyncProtocolViolation)
+			require.Nil(t, ranges)
+		})
+
+		t.Run("if the peer queries too many ranges", func(t *testing.T) {
+			a, b := net.Pipe()
+			defer a.Close()
+
+			tree := construct(t, 4)
+			go func() {
+				defer b.Close()
+
+				conn := newSyncConn(b)
+				_ = conn.writeHello(tree)
+				_, _ = conn.readHello(tree)
+				_ = conn.writeQuery(make([]LeafRange, 9))
+			}()
+
+			ranges, err := ServeSync(a, tree)
+			require.ErrorIs(t, err, ErrSyncProtocolViolation)
+			require.Nil(t, ranges)
+		})
+	})
+}
@@ -0,0 +1,409 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+)
+
+const (
+	// TileHeight is the number of tree levels covered by a single tile.
+	TileHeight = 8
+
+	// TileWidth is the maximum number of hashes contained in a single tile.
+	TileWidth = 1 << TileHeight
+)
+
+// ErrMalformedTile is returned if the content of a tile does not
+// match the number of hashes it is supposed to contain.
+var ErrMalformedTile = errors.New("malformed tile")
+
+// Tile identifies a tile of a tiled transparency log, as described by the
+// C2SP tlog-tiles specification. A tile at level L contains the hashes of up
+// to [TileWidth] consecutive nodes at height L*[TileHeight] in the tree.
+type Tile struct {
+	// Level is the level of the tile, where level 0 contains leaf hashes.
+	Level int
+
+	// Index is the index of the tile within its level.
+	Index uint64
+
+	// Width is the number of hashes in the tile, where anything less
+	// than [TileWidth] is a partial tile.
+	Width int
+}
+
+// Path returns the path of the tile relative to the root of the log,
+// e.g. "tile/0/x001/x234/067.p/8".
+func (t Tile) Path() string {
+	p := "tile/" + strconv.Itoa(t.Level) + "/" + tileIndexPath(t.Index)
+	if t.Width < TileWidth {
+		p += ".p/" + strconv.Itoa(t.Width)
+	}
+	return p
+}
+
+// tileIndexPath encodes the index as groups of 3 decimal digits, where every
+// group but the last is prefixed with an "x".
+func tileIndexPath(n uint64) string {
+	p := fmt.Sprintf("%03d", n%1000)
+	for n /= 1000; n > 0; n /= 1000 {
+		p = fmt.Sprintf("x%03d/", n%1000) + p
+	}
+	return p
+}
+
+// tileFor returns the tile at the given level which contains the node with
+// the given index as it was when the tree contained treeSize leaves.
+func tileFor(level int, nodeIndex, treeSize uint64) Tile {
+	index := nodeIndex / TileWidth
+	nodes := treeSize >> (level * TileHeight)
+	return Tile{
+		Level: level,
+		Index: index,
+		Width: int(min(TileWidth, nodes-index*TileWidth)),
+	}
+}
+
+// splitTile splits the content of the given tile into its hashes.
+func splitTile(tile Tile, data []byte, hashSize int) ([][]byte, error) {
+	if len(data) != tile.Width*hashSize {
+		return nil, ErrMalformedTile
+	}
+
+	hashes := make([][]byte, tile.Width)
+	for i := range hashes {
+		hashes[i] = data[i*hashSize : (i+1)*hashSize]
+	}
+	return hashes, nil
+}
+
+// perfectHash returns the root hash of the perfect subtree whose
+// nodes at its lowest level have the given hashes.
+func perfectHash(hasher hash.Hash, hashes [][]byte) ([]byte, error) {
+	for len(hashes) > 1 {
+		next := make([][]byte, len(hashes)/2)
+		for i := range next {
+			hash, err := RFC6962Scheme.hashChildren(hasher, hashes[2*i], hashes[2*i+1])
+			if err != nil {
+				return nil, err
+			}
+			next[i] = hash
+		}
+		hashes = next
+	}
+	return hashes[0], nil
+}
+
+// TileWriter appends leaves to an RFC 6962 merkle tree and stores it as static
+// tiles in a directory, which can be served by any file server. Full tiles are
+// written as soon as they are complete and never change afterwards. Partial tiles
+// for the right edge of the tree are only written by [TileWriter.Flush], e.g.
+// before publishing a new checkpoint. A TileWriter is not safe for concurrent use.
+type TileWriter struct {
+	dir    string
+	hasher hash.Hash
+	size   uint64
+
+	// edges[l] contains the hashes of the partial tile at level l
+	edges [][][]byte
+}
+
+// OpenTileWriter returns a [TileWriter] for the log in the given directory, which
+// contains a tree of the given size. The partial tiles of the tree must have been
+// written by a previous call to [TileWriter.Flush] at exactly this size.
+func OpenTileWriter(dir string, hasher hash.Hash, size uint64) (*TileWriter, error) {
+	w := &TileWriter{
+		dir:    dir,
+		hasher: hasher,
+		size:   size,
+	}
+
+	for level := 0; size>>(level*TileHeight) > 0; level++ {
+		w.edges = append(w.edges, nil)
+
+		nodes := size >> (level * TileHeight)
+		if nodes%TileWidth == 0 {
+			continue
+		}
+
+		tile := tileFor(level, nodes-1, size)
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(tile.Path())))
+		if err != nil {
+			return nil, err
+		}
+
+		hashes, err := splitTile(tile, data, hasher.Size())
+		if err != nil {
+			return nil, err
+		}
+		w.edges[level] = hashes
+	}
+	return w, nil
+}
+
+// Size returns the number of leaves which have been appended.
+func (w *TileWriter) Size() uint64 {
+	return w.size
+}
+
+// Append hashes the given leaf and appends it to the tree, writing
+// every tile which is completed by it.
+func (w *TileWriter) Append(leaf io.Reader) error {
+	hash, err := RFC6962Scheme.hashLeaf(w.hasher, leaf)
+	if err != nil {
+		return err
+	}
+
+	// the edges are only replaced once every completed tile has been
+	// written, so a failed append can simply be retried
+	size := w.size + 1
+	edges := slices.Clone(w.edges)
+	for level := 0; ; level++ {
+		if level == len(edges) {
+			edges = append(edges, nil)
+		}
+		edges[level] = append(edges[level], hash)
+		if len(edges[level]) < TileWidth {
+			break
+		}
+
+		tile := tileFor(level, size>>(level*TileHeight)-1, size)
+		err := w.writeTile(tile, edges[level])
+		if err != nil {
+			return err
+		}
+
+		hash, err = perfectHash(w.hasher, edges[level])
+		if err != nil {
+			return err
+		}
+		edges[level] = nil
+	}
+
+	w.edges = edges
+	w.size = size
+	return nil
+}
+
+// Flush writes the partial tiles of the right edge of the tree, so that every
+// tile needed to prove inclusion in, or consistency with, the tree at its current
+// size is available.
+func (w *TileWriter) Flush() error {
+	for level, hashes := range w.edges {
+		if len(hashes) == 0 {
+			continue
+		}
+
+		tile := tileFor(level, w.size>>(level*TileHeight)-1, w.size)
+		err := w.writeTile(tile, hashes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Root returns the root hash of the tree containing every appended leaf.
+func (w *TileWriter) Root() ([]byte, error) {
+	if w.size == 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+
+	// the tree is made up of one complete subtree per bit set in its size, whose
+	// nodes are all contained in the partial tiles of the right edge of the tree
+	f := frontier{size: w.size}
+	var start uint64
+	for height := 63; height >= 0; height-- {
+		if w.size>>height&1 == 0 {
+			continue
+		}
+
+		level := height / TileHeight
+		i := (start >> (level * TileHeight)) % TileWidth
+		hash, err := perfectHash(w.hasher, w.edges[level][i:i+1<<(height%TileHeight)])
+		if err != nil {
+			return nil, err
+		}
+		f.hashes = append(f.hashes, hash)
+		start += 1 << height
+	}
+	return f.root(w.hasher, RFC6962Scheme)
+}
+
+// writeTile atomically writes the given tile, unless it already exists.
+func (w *TileWriter) writeTile(tile Tile, hashes [][]byte) error {
+	name := filepath.Join(w.dir, filepath.FromSlash(tile.Path()))
+	if _, err := os.Stat(name); err == nil {
+		return nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), ".tile-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the temporary file no longer exists once it has been renamed
+		_ = os.Remove(f.Name())
+	}()
+
+	for _, hash := range hashes {
+		_, err = f.Write(hash)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// TileFetcher returns the content of the file at the given path, relative to
+// the root of a tiled log. If the file does not exist, an error wrapping
+// [fs.ErrNotExist] must be returned.
+type TileFetcher func(ctx context.Context, path string) ([]byte, error)
+
+// FSTileFetcher returns a [TileFetcher] which reads tiles from the given file system.
+func FSTileFetcher(fsys fs.FS) TileFetcher {
+	return func(ctx context.Context, path string) ([]byte, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return fs.ReadFile(fsys, path)
+	}
+}
+
+// TileClient reconstructs proofs for an RFC 6962 merkle tree from the tiles of a
+// tiled log. Tiles never change once written, so a [TileFetcher] is free to cache
+// them indefinitely.
+type TileClient struct {
+	fetch  TileFetcher
+	hasher hash.Hash
+}
+
+// NewTileClient returns a [TileClient] which fetches tiles with the given fetcher.
+func NewTileClient(hasher hash.Hash, fetch TileFetcher) *TileClient {
+	return &TileClient{
+		fetch:  fetch,
+		hasher: hasher,
+	}
+}
+
+// RootAt returns the root hash of the tree of the given size. It should be
+// compared to the root hash of a checkpoint, to detect modified tiles.
+func (c *TileClient) RootAt(ctx context.Context, size int) ([]byte, error) {
+	if size <= 0 {
+		return nil, ErrAtLeastOneLeafRequired
+	}
+	return c.rangeHashAt(ctx, size)(0, size)
+}
+
+// Prove returns an [InclusionProof] for the leaf at the given index
+// in the tree of the given size.
+func (c *TileClient) Prove(ctx context.Context, leafIndex, treeSize int) (*InclusionProof, error) {
+	if treeSize <= 0 {
+		return nil, ErrInvalidTreeSize
+	}
+	if leafIndex < 0 || leafIndex >= treeSize {
+		return nil, ErrLeafIndexOutOfRange
+	}
+	return proveInclusion(c.rangeHashAt(ctx, treeSize), leafIndex, treeSize)
+}
+
+// ProveConsistency returns a consistency proof between the
+// trees of the given old and new sizes.
+func (c *TileClient) ProveConsistency(ctx context.Context, oldSize, newSize int) ([][]byte, error) {
+	if newSize < 0 || oldSize < 0 || oldSize > newSize {
+		return nil, ErrInvalidTreeSize
+	}
+	return proveConsistency(c.rangeHashAt(ctx, newSize), oldSize, newSize)
+}
+
+// rangeHashAt returns a [rangeHashFunc] for the tree of the given size,
+// which only fetches each tile at most once.
+func (c *TileClient) rangeHashAt(ctx context.Context, treeSize int) rangeHashFunc {
+	tiles := make(map[Tile][][]byte)
+
+	var rangeHash rangeHashFunc
+	rangeHash = func(start, n int) ([]byte, error) {
+		if n&(n-1) == 0 && start%n == 0 {
+			height := 0
+			for 1<<height < n {
+				height++
+			}
+			return c.completeHash(ctx, tiles, height, uint64(start), uint64(treeSize))
+		}
+
+		k := splitPoint(n)
+		left, err := rangeHash(start, k)
+		if err != nil {
+			return nil, err
+		}
+		right, err := rangeHash(start+k, n-k)
+		if err != nil {
+			return nil, err
+		}
+		return RFC6962Scheme.hashChildren(c.hasher, left, right)
+	}
+	return rangeHash
+}
+
+// completeHash returns the hash of the complete subtree of the given height
+// whose first leaf is at the given index.
+func (c *TileClient) completeHash(ctx context.Context, tiles map[Tile][][]byte, height int, start, treeSize uint64) ([]byte, error) {
+	level := height / TileHeight
+	first := start >> (level * TileHeight)
+
+	tile := tileFor(level, first, treeSize)
+	hashes, ok := tiles[tile]
+	if !ok {
+		var err error
+		hashes, err = c.fetchTile(ctx, tile)
+		if err != nil {
+			return nil, err
+		}
+		tiles[tile] = hashes
+	}
+
+	i := first % TileWidth
+	return perfectHash(c.hasher, hashes[i:i+1<<(height%TileHeight)])
+}
+
+// fetchTile fetches the given tile. If it is a partial tile which does not exist,
+// e.g. because the tree was never flushed at exactly that size, the hashes are
+// taken from the full tile instead.
+func (c *TileClient) fetchTile(ctx context.Context, tile Tile) ([][]byte, error) {
+	data, err := c.fetch(ctx, tile.Path())
+	if errors.Is(err, fs.ErrNotExist) && tile.Width < TileWidth {
+		full := Tile{Level: tile.Level, Index: tile.Index, Width: TileWidth}
+
+		data, err = c.fetch(ctx, full.Path())
+		if err == nil && len(data) >= tile.Width*c.hasher.Size() {
+			data = data[:tile.Width*c.hasher.Size()]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return splitTile(tile, data, c.hasher.Size())
+}
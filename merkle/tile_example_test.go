@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func ExampleTileClient() {
+	dir, err := os.MkdirTemp("", "tiles")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	w, err := OpenTileWriter(dir, sha256.New(), 0)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, leaf := range []string{"a", "b", "c"} {
+		err := w.Append(strings.NewReader(leaf))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	root, err := w.Root()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// the tiles could just as well be fetched over HTTP
+	client := NewTileClient(sha256.New(), FSTileFetcher(os.DirFS(dir)))
+
+	proof, err := client.Prove(context.Background(), 1, 3)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = VerifyInclusion(sha256.New(), root, strings.NewReader("b"), proof, WithScheme(RFC6962Scheme))
+	fmt.Println(Tile{Level: 0, Index: 0, Width: 3}.Path())
+	fmt.Println(err == nil)
+	// Output:
+	// tile/0/000.p/3
+	// true
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTile_Path(t *testing.T) {
+	testCases := []struct {
+		Tile Tile
+		Path string
+	}{
+		{Tile: Tile{Level: 0, Index: 0, Width: TileWidth}, Path: "tile/0/000"},
+		{Tile: Tile{Level: 0, Index: 7, Width: 3}, Path: "tile/0/007.p/3"},
+		{Tile: Tile{Level: 1, Index: 1000, Width: TileWidth}, Path: "tile/1/x001/000"},
+		{Tile: Tile{Level: 2, Index: 1234067, Width: 8}, Path: "tile/2/x001/x234/067.p/8"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Path, func(t *testing.T) {
+			require.Equal(t, testCase.Path, testCase.Tile.Path())
+		})
+	}
+}
+
+// writeTiles appends leaves until the tree has each of the given sizes,
+// flushing the writer at every one of them.
+func writeTiles(t *testing.T, w *TileWriter, sizes ...int) {
+	for _, size := range sizes {
+		for i := int(w.Size()); i < size; i++ {
+			err := w.Append(strings.NewReader(strconv.Itoa(i)))
+			require.Nil(t, err)
+		}
+		require.Nil(t, w.Flush())
+	}
+}
+
+func TestTileWriter(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := OpenTileWriter(dir, sha256.New(), 0)
+	require.Nil(t, err)
+
+	sizes := []int{1, 3, 255, 256, 257, 300, 512, 600}
+	writeTiles(t, w, sizes...)
+
+	stored := buildStoredTree(t, NewMemoryNodeStore(), 600)
+	client := NewTileClient(sha256.New(), FSTileFetcher(os.DirFS(dir)))
+	ctx := context.Background()
+
+	t.Run("will write full and partial tiles", func(t *testing.T) {
+		for _, path := range []string{"tile/0/000", "tile/0/001", "tile/0/002.p/88", "tile/1/000.p/2"} {
+			require.FileExists(t, filepath.Join(dir, filepath.FromSlash(path)))
+		}
+	})
+
+	t.Run("will compute the same root as a StoredTree", func(t *testing.T) {
+		root, err := w.Root()
+		require.Nil(t, err)
+
+		expected, err := stored.Root()
+		require.Nil(t, err)
+		require.Equal(t, expected, root)
+	})
+
+	t.Run("will reconstruct the same proofs as a StoredTree", func(t *testing.T) {
+		// 400 was never flushed, so the client must fall back to full tiles
+		for _, size := range append(sizes, 400) {
+			expectedRoot, err := stored.RootAt(size)
+			require.Nil(t, err)
+
+			root, err := client.RootAt(ctx, size)
+			require.Nil(t, err)
+			require.Equal(t, expectedRoot, root)
+
+			for _, i := range []int{0, 1, size / 2, size - 2, size - 1} {
+				if i < 0 || i >= size {
+					continue
+				}
+
+				expected, err := stored.Prove(i, size)
+				require.Nil(t, err)
+
+				proof, err := client.Prove(ctx, i, size)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+
+			for _, oldSize := range append(sizes, 400) {
+				if oldSize > size {
+					continue
+				}
+
+				expected, err := stored.ProveConsistency(oldSize, size)
+				require.Nil(t, err)
+
+				proof, err := client.ProveConsistency(ctx, oldSize, size)
+				require.Nil(t, err)
+				require.Equal(t, expected, proof)
+			}
+		}
+	})
+
+	t.Run("will continue where a previous writer left off", func(t *testing.T) {
+		resumed, err := OpenTileWriter(dir, sha256.New(), 600)
+		require.Nil(t, err)
+
+		writeTiles(t, resumed, 700)
+		root, err := resumed.Root()
+		require.Nil(t, err)
+
+		expected := buildStoredTree(t, NewMemoryNodeStore(), 700)
+		expectedRoot, err := expected.Root()
+		require.Nil(t, err)
+		require.Equal(t, expectedRoot, root)
+
+		root, err = client.RootAt(ctx, 700)
+		require.Nil(t, err)
+		require.Equal(t, expectedRoot, root)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the tree is empty", func(t *testing.T) {
+			empty, err := OpenTileWriter(t.TempDir(), sha256.New(), 0)
+			require.Nil(t, err)
+
+			_, err = empty.Root()
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if a tile can not be written, without corrupting the writer", func(t *testing.T) {
+			dir := t.TempDir()
+			w, err := OpenTileWriter(dir, sha256.New(), 0)
+			require.Nil(t, err)
+			writeTiles(t, w, TileWidth-1)
+
+			// a file in place of the tile directory makes every tile write fail
+			blocked := filepath.Join(dir, "tile")
+			require.Nil(t, os.RemoveAll(blocked))
+			require.Nil(t, os.WriteFile(blocked, nil, 0o644))
+
+			leaf := strconv.Itoa(TileWidth - 1)
+			err = w.Append(strings.NewReader(leaf))
+			require.NotNil(t, err)
+			require.Equal(t, uint64(TileWidth-1), w.Size())
+
+			require.Nil(t, os.Remove(blocked))
+			err = w.Append(strings.NewReader(leaf))
+			require.Nil(t, err)
+			require.Nil(t, w.Flush())
+
+			info, err := os.Stat(filepath.Join(dir, "tile", "0", "000"))
+			require.Nil(t, err)
+			require.Equal(t, int64(TileWidth*sha256.Size), info.Size())
+
+			expected, err := buildStoredTree(t, NewMemoryNodeStore(), TileWidth).Root()
+			require.Nil(t, err)
+
+			root, err := NewTileClient(sha256.New(), FSTileFetcher(os.DirFS(dir))).RootAt(ctx, TileWidth)
+			require.Nil(t, err)
+			require.Equal(t, expected, root)
+		})
+
+		t.Run("if the partial tiles of the tree were never written", func(t *testing.T) {
+			_, err := OpenTileWriter(dir, sha256.New(), 601)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run("if the leaf index is out of range", func(t *testing.T) {
+			_, err := client.Prove(ctx, 10, 10)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+
+		t.Run("if the size is invalid", func(t *testing.T) {
+			_, err := client.Prove(ctx, 0, 0)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+
+			_, err = client.ProveConsistency(ctx, 10, 5)
+			require.ErrorIs(t, err, ErrInvalidTreeSize)
+
+			_, err = client.RootAt(ctx, 0)
+			require.ErrorIs(t, err, ErrAtLeastOneLeafRequired)
+		})
+
+		t.Run("if a tile does not exist", func(t *testing.T) {
+			_, err := client.RootAt(ctx, 1000)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+
+		t.Run("if a tile is malformed", func(t *testing.T) {
+			client := NewTileClient(sha256.New(), func(ctx context.Context, path string) ([]byte, error) {
+				return make([]byte, 10), nil
+			})
+
+			_, err := client.Prove(ctx, 0, 2)
+			require.ErrorIs(t, err, ErrMalformedTile)
+		})
+
+		t.Run("if the fetch fails", func(t *testing.T) {
+			errFetchFailed := errors.New("failed to fetch")
+			client := NewTileClient(sha256.New(), func(ctx context.Context, path string) ([]byte, error) {
+				return nil, errFetchFailed
+			})
+
+			_, err := client.ProveConsistency(ctx, 1, 2)
+			require.ErrorIs(t, err, errFetchFailed)
+		})
+
+		t.Run("if the context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := client.RootAt(ctx, 600)
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	})
+}
+
+func TestTileWriter_Levels(t *testing.T) {
+	const size = TileWidth*TileWidth + 3
+
+	dir := t.TempDir()
+	w, err := OpenTileWriter(dir, sha256.New(), 0)
+	require.Nil(t, err)
+	writeTiles(t, w, size)
+	require.FileExists(t, filepath.Join(dir, "tile", "2", "000.p", "1"))
+
+	stored := buildStoredTree(t, NewMemoryNodeStore(), size)
+	expected, err := stored.Root()
+	require.Nil(t, err)
+
+	root, err := w.Root()
+	require.Nil(t, err)
+	require.Equal(t, expected, root)
+
+	client := NewTileClient(sha256.New(), FSTileFetcher(os.DirFS(dir)))
+	proof, err := client.Prove(context.Background(), 1000, size)
+	require.Nil(t, err)
+
+	err = VerifyInclusion(sha256.New(), root, strings.NewReader("1000"), proof, WithScheme(RFC6962Scheme))
+	require.Nil(t, err)
+}
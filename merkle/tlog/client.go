@@ -0,0 +1,244 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package tlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/z5labs/sdk-go/merkle"
+	"github.com/z5labs/sdk-go/try"
+)
+
+// maxResponseSize limits how much of a response body is read by a [Client].
+const maxResponseSize = 1 << 20
+
+var (
+	// ErrUnexpectedStatus is returned if the server responds
+	// with any status code other than 200 OK.
+	ErrUnexpectedStatus = errors.New("unexpected status code")
+
+	// ErrOriginMismatch is returned if a checkpoint is
+	// for a different log than the client expects.
+	ErrOriginMismatch = errors.New("checkpoint origin mismatch")
+)
+
+// ClientOption configures a [Client].
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the [http.Client] used to send requests,
+// which defaults to [http.DefaultClient].
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// Client talks to a [Server] and verifies every response. Checkpoints must be
+// signed by the log and every checkpoint must be consistent with the latest one
+// the client has previously verified, so a log which rewrites its history is
+// detected as soon as the client sees both versions.
+type Client struct {
+	baseURL  string
+	origin   string
+	verifier *merkle.NoteVerifier
+	http     *http.Client
+
+	mu     sync.Mutex
+	latest *merkle.TreeHead
+}
+
+// NewClient returns a [Client] for the log with the given origin served at
+// the given base URL, whose checkpoints are verified with the given verifier.
+func NewClient(baseURL, origin string, verifier *merkle.NoteVerifier, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		origin:   origin,
+		verifier: verifier,
+		http:     http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Checkpoint fetches and verifies the latest checkpoint of the log.
+func (c *Client) Checkpoint(ctx context.Context) (*merkle.TreeHead, error) {
+	note, err := c.do(ctx, http.MethodGet, "/checkpoint", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.verifyCheckpoint(ctx, note)
+}
+
+// AddEntry appends the given entry to the log and returns its index, along with
+// the checkpoint of the first tree containing it. The inclusion of the entry in
+// the returned checkpoint is verified before returning.
+func (c *Client) AddEntry(ctx context.Context, entry []byte) (uint64, *merkle.TreeHead, error) {
+	b, err := c.do(ctx, http.MethodPost, "/add-entry", entry)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var resp addEntryResponse
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	head, err := c.verifyCheckpoint(ctx, []byte(resp.Checkpoint))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = c.VerifyInclusion(ctx, entry, resp.Index, head)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.Index, head, nil
+}
+
+// VerifyInclusion fetches an inclusion proof for the entry at the given index
+// and verifies that the entry is contained in the tree of the given checkpoint,
+// which should have been returned by this client.
+func (c *Client) VerifyInclusion(ctx context.Context, entry []byte, index uint64, head *merkle.TreeHead) error {
+	if index >= head.Size {
+		return merkle.ErrLeafIndexOutOfRange
+	}
+
+	query := url.Values{}
+	query.Set("index", strconv.FormatUint(index, 10))
+	query.Set("size", strconv.FormatUint(head.Size, 10))
+
+	b, err := c.do(ctx, http.MethodGet, "/proof/inclusion?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	var resp inclusionProofResponse
+	err = json.Unmarshal(b, &resp)
+	if err != nil {
+		return err
+	}
+
+	proof, err := merkle.NewInclusionProof(int(index), int(head.Size), resp.Hashes)
+	if err != nil {
+		return err
+	}
+	return merkle.VerifyInclusion(sha256.New(), head.Root, bytes.NewReader(entry), proof, merkle.WithScheme(merkle.RFC6962Scheme))
+}
+
+// verifyCheckpoint opens the given signed checkpoint and verifies that it is
+// consistent with the latest checkpoint verified by the client, which it then
+// replaces if the given checkpoint is for a larger tree.
+func (c *Client) verifyCheckpoint(ctx context.Context, note []byte) (*merkle.TreeHead, error) {
+	head, err := merkle.OpenTreeHead(note, 1, c.verifier)
+	if err != nil {
+		return nil, err
+	}
+	if head.Origin != c.origin {
+		return nil, ErrOriginMismatch
+	}
+
+	for {
+		c.mu.Lock()
+		latest := c.latest
+		c.mu.Unlock()
+
+		// the consistency proof is fetched without holding the lock,
+		// so concurrent calls never wait on each others requests
+		if latest != nil {
+			// concurrent requests may return checkpoints out of order,
+			// so an older checkpoint is verified against the latest one
+			oldHead, newHead := latest, head
+			if head.Size < latest.Size {
+				oldHead, newHead = head, latest
+			}
+
+			err = c.verifyConsistency(ctx, oldHead, newHead)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		c.mu.Lock()
+		if c.latest != latest {
+			// another checkpoint was verified in the meantime,
+			// so the checkpoint must be verified against it instead
+			c.mu.Unlock()
+			continue
+		}
+		if latest == nil || head.Size > latest.Size {
+			c.latest = head
+		}
+		c.mu.Unlock()
+		return head, nil
+	}
+}
+
+func (c *Client) verifyConsistency(ctx context.Context, oldHead, newHead *merkle.TreeHead) error {
+	var hashes [][]byte
+	if oldHead.Size > 0 && oldHead.Size < newHead.Size {
+		query := url.Values{}
+		query.Set("old", strconv.FormatUint(oldHead.Size, 10))
+		query.Set("new", strconv.FormatUint(newHead.Size, 10))
+
+		b, err := c.do(ctx, http.MethodGet, "/proof/consistency?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+
+		var resp consistencyProofResponse
+		err = json.Unmarshal(b, &resp)
+		if err != nil {
+			return err
+		}
+		hashes = resp.Hashes
+	}
+
+	return merkle.VerifyConsistency(
+		sha256.New(),
+		int(oldHead.Size),
+		int(newHead.Size),
+		oldHead.Root,
+		newHead.Root,
+		hashes,
+		merkle.WithScheme(merkle.RFC6962Scheme),
+	)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (b []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer try.Close(&err, resp.Body)
+
+	b, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+	return b, nil
+}
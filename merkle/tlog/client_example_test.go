@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package tlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/z5labs/sdk-go/merkle"
+)
+
+func ExampleClient() {
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	signer, err := merkle.NewNoteSigner("example.com/log", key)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	s, err := NewServer("example.com/log", signer, merkle.NewMemoryNodeStore())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "example.com/log", signer.Verifier())
+	for _, entry := range []string{"a", "b", "c"} {
+		index, head, err := client.AddEntry(context.Background(), []byte(entry))
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(index, head.Size)
+	}
+	// Output:
+	// 0 1
+	// 1 2
+	// 2 3
+}
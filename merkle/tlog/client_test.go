@@ -0,0 +1,188 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package tlog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/z5labs/sdk-go/merkle"
+)
+
+var errCloseFailed = errors.New("failed to close")
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type closeFailBody struct {
+	io.ReadCloser
+}
+
+func (b closeFailBody) Close() error {
+	return errors.Join(b.ReadCloser.Close(), errCloseFailed)
+}
+
+func TestClient(t *testing.T) {
+	signer := newTestSigner(t, testOrigin, 1)
+	ctx := context.Background()
+
+	t.Run("will verify added entries and checkpoints", func(t *testing.T) {
+		srv := httptest.NewServer(newTestServer(t, signer))
+		defer srv.Close()
+
+		client := NewClient(srv.URL, testOrigin, signer.Verifier(), WithHTTPClient(srv.Client()))
+
+		var heads []*merkle.TreeHead
+		for i := range 10 {
+			index, head, err := client.AddEntry(ctx, []byte(strconv.Itoa(i)))
+			require.Nil(t, err)
+			require.Equal(t, uint64(i), index)
+			require.Equal(t, uint64(i+1), head.Size)
+			heads = append(heads, head)
+		}
+
+		latest, err := client.Checkpoint(ctx)
+		require.Nil(t, err)
+		require.Equal(t, uint64(10), latest.Size)
+
+		for i, head := range heads {
+			err := client.VerifyInclusion(ctx, []byte(strconv.Itoa(i)), uint64(i), latest)
+			require.Nil(t, err)
+
+			err = client.VerifyInclusion(ctx, []byte(strconv.Itoa(i)), uint64(i), head)
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the checkpoint is signed by an unknown key", func(t *testing.T) {
+			srv := httptest.NewServer(newTestServer(t, newTestSigner(t, testOrigin, 2)))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, testOrigin, signer.Verifier())
+			_, err := client.Checkpoint(ctx)
+			require.ErrorIs(t, err, merkle.ErrInsufficientSignatures)
+		})
+
+		t.Run("if the checkpoint is for a different log", func(t *testing.T) {
+			srv := httptest.NewServer(newTestServer(t, signer))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, "example.com/other", signer.Verifier())
+			_, err := client.Checkpoint(ctx)
+			require.ErrorIs(t, err, ErrOriginMismatch)
+		})
+
+		t.Run("if the log rewrites its history", func(t *testing.T) {
+			var current atomic.Pointer[Server]
+			current.Store(newTestServer(t, signer, "a", "b"))
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				current.Load().ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, testOrigin, signer.Verifier())
+			_, err := client.Checkpoint(ctx)
+			require.Nil(t, err)
+
+			current.Store(newTestServer(t, signer, "a", "c"))
+			_, err = client.Checkpoint(ctx)
+			require.ErrorIs(t, err, merkle.ErrRootMismatch)
+
+			current.Store(newTestServer(t, signer, "a", "c", "d"))
+			_, err = client.Checkpoint(ctx)
+			require.ErrorIs(t, err, merkle.ErrRootMismatch)
+		})
+
+		t.Run("if the log serves an invalid inclusion proof", func(t *testing.T) {
+			s := newTestServer(t, signer, "a", "b", "c")
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/proof/inclusion" {
+					// serve the proof of a different leaf
+					r.URL.RawQuery = "index=0&size=3"
+				}
+				s.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, testOrigin, signer.Verifier())
+			head, err := client.Checkpoint(ctx)
+			require.Nil(t, err)
+
+			err = client.VerifyInclusion(ctx, []byte("b"), 1, head)
+			require.ErrorIs(t, err, merkle.ErrRootMismatch)
+
+			err = client.VerifyInclusion(ctx, []byte("b"), 0, head)
+			require.ErrorIs(t, err, merkle.ErrRootMismatch)
+		})
+
+		t.Run("if the log serves an inclusion proof for a different tree size", func(t *testing.T) {
+			s := newTestServer(t, signer, "a", "b", "c")
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/proof/inclusion" {
+					r.URL.RawQuery = "index=0&size=2"
+				}
+				s.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, testOrigin, signer.Verifier())
+			head, err := client.Checkpoint(ctx)
+			require.Nil(t, err)
+
+			err = client.VerifyInclusion(ctx, []byte("a"), 0, head)
+			require.ErrorIs(t, err, merkle.ErrInvalidProof)
+		})
+
+		t.Run("if the index is not in the tree", func(t *testing.T) {
+			client := NewClient("http://localhost", testOrigin, signer.Verifier())
+
+			err := client.VerifyInclusion(ctx, []byte("a"), 1, &merkle.TreeHead{Size: 1})
+			require.ErrorIs(t, err, merkle.ErrLeafIndexOutOfRange)
+		})
+
+		t.Run("if the server does not respond with 200 OK", func(t *testing.T) {
+			srv := httptest.NewServer(http.NotFoundHandler())
+			defer srv.Close()
+
+			client := NewClient(srv.URL+"/", testOrigin, signer.Verifier())
+			_, _, err := client.AddEntry(ctx, []byte(strings.Repeat("a", 10)))
+			require.ErrorIs(t, err, ErrUnexpectedStatus)
+		})
+
+		t.Run("if the response body can not be closed", func(t *testing.T) {
+			srv := httptest.NewServer(newTestServer(t, signer))
+			defer srv.Close()
+
+			hc := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					resp, err := http.DefaultTransport.RoundTrip(req)
+					if err != nil {
+						return nil, err
+					}
+					resp.Body = closeFailBody{resp.Body}
+					return resp, nil
+				}),
+			}
+
+			client := NewClient(srv.URL, testOrigin, signer.Verifier(), WithHTTPClient(hc))
+			_, err := client.Checkpoint(ctx)
+			require.ErrorIs(t, err, errCloseFailed)
+		})
+	})
+}
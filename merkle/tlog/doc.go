@@ -0,0 +1,8 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package tlog provides a minimal transparency log server, exposed as an
+// [net/http.Handler], and a client which verifies every response it receives.
+package tlog
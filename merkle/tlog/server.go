@@ -0,0 +1,226 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package tlog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/z5labs/sdk-go/merkle"
+)
+
+// MaxEntrySize is the maximum size in bytes of an entry accepted by a [Server].
+const MaxEntrySize = 1 << 20
+
+// Server is an [http.Handler] for an append-only log of entries, whose merkle
+// tree is hashed with SHA-256 as described in RFC 6962. It exposes the
+// following endpoints:
+//
+//   - POST /add-entry appends the request body as a new entry
+//   - GET /checkpoint returns the latest signed checkpoint
+//   - GET /proof/inclusion?index=<i>&size=<n> returns an inclusion proof
+//   - GET /proof/consistency?old=<m>&new=<n> returns a consistency proof
+type Server struct {
+	origin string
+	signer *merkle.NoteSigner
+	mux    *http.ServeMux
+
+	mu         sync.Mutex
+	tree       *merkle.StoredTree
+	checkpoint []byte
+}
+
+// NewServer returns a [Server] for the log with the given origin, whose
+// checkpoints are signed by the given signer. The tree is persisted to the
+// given store, so a log can be resumed from a previously used store.
+func NewServer(origin string, signer *merkle.NoteSigner, store merkle.NodeStore) (*Server, error) {
+	tree, err := merkle.NewStoredTree(sha256.New(), store, merkle.WithScheme(merkle.RFC6962Scheme))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		origin: origin,
+		signer: signer,
+		mux:    http.NewServeMux(),
+		tree:   tree,
+	}
+	err = s.sign()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mux.HandleFunc("POST /add-entry", s.addEntry)
+	s.mux.HandleFunc("GET /checkpoint", s.latestCheckpoint)
+	s.mux.HandleFunc("GET /proof/inclusion", s.inclusionProof)
+	s.mux.HandleFunc("GET /proof/consistency", s.consistencyProof)
+	return s, nil
+}
+
+// ServeHTTP implements the [http.Handler] interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// sign replaces the latest checkpoint with one for the current tree.
+func (s *Server) sign() error {
+	size := s.tree.Size()
+
+	// the root hash of the empty tree is the hash of the empty string
+	root := sha256.New().Sum(nil)
+	if size > 0 {
+		var err error
+		root, err = s.tree.Root()
+		if err != nil {
+			return err
+		}
+	}
+
+	note, err := merkle.SignTreeHead(&merkle.TreeHead{
+		Origin:    s.origin,
+		Size:      uint64(size),
+		Root:      root,
+		Timestamp: time.Now(),
+	}, s.signer)
+	if err != nil {
+		return err
+	}
+
+	s.checkpoint = note
+	return nil
+}
+
+type addEntryResponse struct {
+	Index      uint64 `json:"index"`
+	Checkpoint string `json:"checkpoint"`
+}
+
+func (s *Server) addEntry(w http.ResponseWriter, r *http.Request) {
+	entry, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxEntrySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.tree.Append(bytes.NewReader(entry))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = s.sign()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, addEntryResponse{
+		Index:      uint64(s.tree.Size() - 1),
+		Checkpoint: string(s.checkpoint),
+	})
+}
+
+func (s *Server) latestCheckpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	checkpoint := s.checkpoint
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writeBody(w, checkpoint)
+}
+
+func (s *Server) inclusionProof(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.URL.Query().Get("index"))
+	if err != nil {
+		http.Error(w, "invalid index", http.StatusBadRequest)
+		return
+	}
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proof, err := s.tree.Prove(index, size)
+	if err != nil {
+		writeProofError(w, err)
+		return
+	}
+	writeJSON(w, inclusionProofResponse{Hashes: proof.Hashes()})
+}
+
+type inclusionProofResponse struct {
+	Hashes [][]byte `json:"hashes"`
+}
+
+type consistencyProofResponse struct {
+	Hashes [][]byte `json:"hashes"`
+}
+
+func (s *Server) consistencyProof(w http.ResponseWriter, r *http.Request) {
+	oldSize, err := strconv.Atoi(r.URL.Query().Get("old"))
+	if err != nil {
+		http.Error(w, "invalid old size", http.StatusBadRequest)
+		return
+	}
+	newSize, err := strconv.Atoi(r.URL.Query().Get("new"))
+	if err != nil {
+		http.Error(w, "invalid new size", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes, err := s.tree.ProveConsistency(oldSize, newSize)
+	if err != nil {
+		writeProofError(w, err)
+		return
+	}
+	writeJSON(w, consistencyProofResponse{Hashes: hashes})
+}
+
+func writeProofError(w http.ResponseWriter, err error) {
+	if errors.Is(err, merkle.ErrInvalidTreeSize) || errors.Is(err, merkle.ErrLeafIndexOutOfRange) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeBody(w, b)
+}
+
+func writeBody(w http.ResponseWriter, b []byte) {
+	// the response can only fail to be written if the client went
+	// away, in which case there is no one left to report it to
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package tlog
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/z5labs/sdk-go/merkle"
+)
+
+const testOrigin = "example.com/log"
+
+func newTestSigner(t *testing.T, name string, seed byte) *merkle.NoteSigner {
+	signer, err := merkle.NewNoteSigner(name, ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize)))
+	require.Nil(t, err)
+	return signer
+}
+
+func newTestServer(t *testing.T, signer *merkle.NoteSigner, entries ...string) *Server {
+	s, err := NewServer(testOrigin, signer, merkle.NewMemoryNodeStore())
+	require.Nil(t, err)
+
+	for _, entry := range entries {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/add-entry", strings.NewReader(entry)))
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+	return s
+}
+
+func TestServer(t *testing.T) {
+	signer := newTestSigner(t, testOrigin, 1)
+
+	t.Run("will sign a checkpoint for the empty tree", func(t *testing.T) {
+		s := newTestServer(t, signer)
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/checkpoint", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		head, err := merkle.OpenTreeHead(w.Body.Bytes(), 1, signer.Verifier())
+		require.Nil(t, err)
+		require.Equal(t, testOrigin, head.Origin)
+		require.Equal(t, uint64(0), head.Size)
+		require.Equal(t, sha256.New().Sum(nil), head.Root)
+	})
+
+	t.Run("will return the index and checkpoint of an added entry", func(t *testing.T) {
+		s := newTestServer(t, signer, "a", "b")
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/add-entry", strings.NewReader("c")))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp addEntryResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.Nil(t, err)
+		require.Equal(t, uint64(2), resp.Index)
+
+		head, err := merkle.OpenTreeHead([]byte(resp.Checkpoint), 1, signer.Verifier())
+		require.Nil(t, err)
+		require.Equal(t, uint64(3), head.Size)
+
+		tree, err := merkle.NewBinaryTree(sha256.New(), []*strings.Reader{
+			strings.NewReader("a"),
+			strings.NewReader("b"),
+			strings.NewReader("c"),
+		}, merkle.WithScheme(merkle.RFC6962Scheme))
+		require.Nil(t, err)
+		require.Equal(t, tree.Hash(), head.Root)
+	})
+
+	t.Run("will return the sibling hashes of an inclusion proof", func(t *testing.T) {
+		s := newTestServer(t, signer, "a", "b", "c")
+
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/proof/inclusion?index=1&size=3", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp struct {
+			Hashes [][]byte `json:"hashes"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.Nil(t, err)
+
+		tree, err := merkle.NewBinaryTree(sha256.New(), []*strings.Reader{
+			strings.NewReader("a"),
+			strings.NewReader("b"),
+			strings.NewReader("c"),
+		}, merkle.WithScheme(merkle.RFC6962Scheme))
+		require.Nil(t, err)
+
+		proof, err := tree.Prove(1)
+		require.Nil(t, err)
+		require.Equal(t, proof.Hashes(), resp.Hashes)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		s := newTestServer(t, signer, "a", "b", "c")
+
+		testCases := []struct {
+			Name   string
+			Method string
+			Target string
+			Body   string
+			Status int
+		}{
+			{
+				Name:   "if the entry is too large",
+				Method: http.MethodPost,
+				Target: "/add-entry",
+				Body:   strings.Repeat("a", MaxEntrySize+1),
+				Status: http.StatusRequestEntityTooLarge,
+			},
+			{
+				Name:   "if the index is missing",
+				Method: http.MethodGet,
+				Target: "/proof/inclusion?size=3",
+				Status: http.StatusBadRequest,
+			},
+			{
+				Name:   "if the index is out of range",
+				Method: http.MethodGet,
+				Target: "/proof/inclusion?index=3&size=3",
+				Status: http.StatusBadRequest,
+			},
+			{
+				Name:   "if the tree size is larger than the tree",
+				Method: http.MethodGet,
+				Target: "/proof/consistency?old=1&new=4",
+				Status: http.StatusBadRequest,
+			},
+			{
+				Name:   "if the new size is not a number",
+				Method: http.MethodGet,
+				Target: "/proof/consistency?old=1&new=x",
+				Status: http.StatusBadRequest,
+			},
+			{
+				Name:   "if the method is not allowed",
+				Method: http.MethodGet,
+				Target: "/add-entry",
+				Status: http.StatusMethodNotAllowed,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				s.ServeHTTP(w, httptest.NewRequest(testCase.Method, testCase.Target, strings.NewReader(testCase.Body)))
+				require.Equal(t, testCase.Status, w.Code)
+			})
+		}
+
+		t.Run("if the origin is invalid", func(t *testing.T) {
+			_, err := NewServer("", signer, merkle.NewMemoryNodeStore())
+			require.ErrorIs(t, err, merkle.ErrMalformedTreeHead)
+		})
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// TraversalOrder determines the order in which [BinaryTree.All] visits nodes.
+type TraversalOrder uint8
+
+const (
+	// PreOrder visits a node before its left and right subtrees.
+	PreOrder TraversalOrder = iota
+
+	// PostOrder visits a node after its left and right subtrees.
+	PostOrder
+
+	// LevelOrder visits every node of a level, from left to right,
+	// before any node of the level below it, starting at the root.
+	LevelOrder
+)
+
+// String implements the [fmt.Stringer] interface.
+func (o TraversalOrder) String() string {
+	switch o {
+	case PreOrder:
+		return "pre_order"
+	case PostOrder:
+		return "post_order"
+	case LevelOrder:
+		return "level_order"
+	default:
+		return fmt.Sprintf("TraversalOrder(%d)", uint8(o))
+	}
+}
+
+// Size returns the number of leaves contained in this tree.
+func (t *BinaryTree) Size() int {
+	return t.size
+}
+
+// Height returns the number of edges on the longest path from this tree
+// to one of its leaves, which is 0 if this tree is a leaf.
+func (t *BinaryTree) Height() int {
+	// the left subtree always contains the largest power of 2 leaves
+	// which is less than the size, so it is never shorter than the right
+	return bits.Len(uint(t.size - 1))
+}
+
+// Leaf returns the leaf at the given index in logarithmic time.
+func (t *BinaryTree) Leaf(i int) (*BinaryTree, error) {
+	if i < 0 || i >= t.size {
+		return nil, ErrLeafIndexOutOfRange
+	}
+	return t.subtree(i, 1), nil
+}
+
+// Leaves returns an iterator over every leaf, along with its index,
+// from left to right.
+func (t *BinaryTree) Leaves() iter.Seq2[int, *BinaryTree] {
+	return func(yield func(int, *BinaryTree) bool) {
+		var leaves func(node *BinaryTree, offset int) bool
+		leaves = func(node *BinaryTree, offset int) bool {
+			if node.IsLeaf() {
+				return yield(offset, node)
+			}
+			return leaves(node.left, offset) && leaves(node.right, offset+node.left.size)
+		}
+		leaves(t, 0)
+	}
+}
+
+// All returns an iterator over every node, including the leaves
+// and this tree itself, in the given order.
+func (t *BinaryTree) All(order TraversalOrder) iter.Seq[*BinaryTree] {
+	return func(yield func(*BinaryTree) bool) {
+		switch order {
+		case PreOrder:
+			preOrder(t, yield)
+		case PostOrder:
+			postOrder(t, yield)
+		case LevelOrder:
+			levelOrder(t, yield)
+		}
+	}
+}
+
+func preOrder(node *BinaryTree, yield func(*BinaryTree) bool) bool {
+	if !yield(node) {
+		return false
+	}
+	if node.IsLeaf() {
+		return true
+	}
+	return preOrder(node.left, yield) && preOrder(node.right, yield)
+}
+
+func postOrder(node *BinaryTree, yield func(*BinaryTree) bool) bool {
+	if !node.IsLeaf() && !(postOrder(node.left, yield) && postOrder(node.right, yield)) {
+		return false
+	}
+	return yield(node)
+}
+
+func levelOrder(root *BinaryTree, yield func(*BinaryTree) bool) {
+	level := []*BinaryTree{root}
+	for len(level) > 0 {
+		var next []*BinaryTree
+		for _, node := range level {
+			if !yield(node) {
+				return
+			}
+			if !node.IsLeaf() {
+				next = append(next, node.left, node.right)
+			}
+		}
+		level = next
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryTree_Leaves(t *testing.T) {
+	t.Run("will yield every leaf in order", func(t *testing.T) {
+		for n := 1; n <= 17; n++ {
+			tree, err := NewBinaryTree(sha256.New(), leafValues(n))
+			require.Nil(t, err)
+
+			var count int
+			for i, leaf := range tree.Leaves() {
+				require.Equal(t, count, i)
+				require.True(t, leaf.IsLeaf())
+
+				expected, err := hashAll(sha256.New(), strings.NewReader(strconv.Itoa(i)))
+				require.Nil(t, err)
+				require.Equal(t, expected, leaf.Hash())
+				count++
+			}
+			require.Equal(t, n, count)
+		}
+	})
+
+	t.Run("will stop if the iteration is stopped", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(9))
+		require.Nil(t, err)
+
+		var indices []int
+		for i := range tree.Leaves() {
+			if i == 3 {
+				break
+			}
+			indices = append(indices, i)
+		}
+		require.Equal(t, []int{0, 1, 2}, indices)
+	})
+}
+
+func TestBinaryTree_All(t *testing.T) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(5))
+	require.Nil(t, err)
+
+	// the tree has the following shape:
+	//
+	//          root
+	//         /    \
+	//       l4      4
+	//      /  \
+	//     a    b
+	//    / \  / \
+	//   0  1  2  3
+	root, l4, leaf4 := tree, tree.Left(), tree.Right()
+	a, b := l4.Left(), l4.Right()
+	leaf0, leaf1, leaf2, leaf3 := a.Left(), a.Right(), b.Left(), b.Right()
+
+	testCases := []struct {
+		Order    TraversalOrder
+		Expected []*BinaryTree
+	}{
+		{
+			Order:    PreOrder,
+			Expected: []*BinaryTree{root, l4, a, leaf0, leaf1, b, leaf2, leaf3, leaf4},
+		},
+		{
+			Order:    PostOrder,
+			Expected: []*BinaryTree{leaf0, leaf1, a, leaf2, leaf3, b, l4, leaf4, root},
+		},
+		{
+			Order:    LevelOrder,
+			Expected: []*BinaryTree{root, l4, leaf4, a, b, leaf0, leaf1, leaf2, leaf3},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("will visit every node in "+testCase.Order.String(), func(t *testing.T) {
+			require.Equal(t, testCase.Expected, slices.Collect(tree.All(testCase.Order)))
+		})
+
+		t.Run("will stop if the iteration is stopped in "+testCase.Order.String(), func(t *testing.T) {
+			var nodes []*BinaryTree
+			for node := range tree.All(testCase.Order) {
+				nodes = append(nodes, node)
+				if len(nodes) == 4 {
+					break
+				}
+			}
+			require.Equal(t, testCase.Expected[:4], nodes)
+		})
+	}
+}
+
+func TestBinaryTree_Leaf(t *testing.T) {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(11))
+	require.Nil(t, err)
+
+	t.Run("will return the same leaf as Leaves", func(t *testing.T) {
+		for i, expected := range tree.Leaves() {
+			leaf, err := tree.Leaf(i)
+			require.Nil(t, err)
+			require.Same(t, expected, leaf)
+		}
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the index is out of range", func(t *testing.T) {
+			_, err := tree.Leaf(-1)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+
+			_, err = tree.Leaf(11)
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+	})
+}
+
+func TestBinaryTree_Height(t *testing.T) {
+	for n := 1; n <= 33; n++ {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(n))
+		require.Nil(t, err)
+		require.Equal(t, n, tree.Size())
+
+		var height func(*BinaryTree) int
+		height = func(node *BinaryTree) int {
+			if node.IsLeaf() {
+				return 0
+			}
+			return 1 + max(height(node.Left()), height(node.Right()))
+		}
+		require.Equal(t, height(tree), tree.Height(), n)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformedTreeHead is returned when decoding a [TreeHead] from text
+// which is not a valid checkpoint.
+var ErrMalformedTreeHead = errors.New("malformed tree head")
+
+// TreeHead describes the state of a log, identified by its origin, at a point
+// in time. Once signed, it attests that the signer has seen a tree of the given
+// size with the given root hash.
+type TreeHead struct {
+	// Origin uniquely identifies the log, e.g. "example.com/log".
+	Origin string
+
+	// Size is the number of leaves in the tree.
+	Size uint64
+
+	// Root is the root hash of the tree.
+	Root []byte
+
+	// Timestamp is when the tree head was produced. It is optional
+	// and omitted from the text encoding if it is the zero value.
+	Timestamp time.Time
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+//
+// The encoding follows the checkpoint format used by transparency logs: the origin,
+// the decimal size and the base64 encoded root hash, each on their own line, followed
+// by a "timestamp" extension line holding the Unix time in milliseconds, if set.
+func (h *TreeHead) MarshalText() ([]byte, error) {
+	if h.Origin == "" || strings.Contains(h.Origin, "\n") || len(h.Root) == 0 {
+		return nil, ErrMalformedTreeHead
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n%d\n%s\n", h.Origin, h.Size, base64.StdEncoding.EncodeToString(h.Root))
+	if !h.Timestamp.IsZero() {
+		fmt.Fprintf(&buf, "timestamp %d\n", h.Timestamp.UnixMilli())
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// Unknown extension lines are ignored.
+func (h *TreeHead) UnmarshalText(b []byte) error {
+	if len(b) == 0 || b[len(b)-1] != '\n' {
+		return ErrMalformedTreeHead
+	}
+
+	lines := strings.Split(string(b[:len(b)-1]), "\n")
+	if len(lines) < 3 || lines[0] == "" {
+		return ErrMalformedTreeHead
+	}
+
+	size, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return ErrMalformedTreeHead
+	}
+
+	root, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil || len(root) == 0 {
+		return ErrMalformedTreeHead
+	}
+
+	head := TreeHead{
+		Origin: lines[0],
+		Size:   size,
+		Root:   root,
+	}
+	for _, line := range lines[3:] {
+		if line == "" {
+			return ErrMalformedTreeHead
+		}
+
+		v, ok := strings.CutPrefix(line, "timestamp ")
+		if !ok {
+			continue
+		}
+
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return ErrMalformedTreeHead
+		}
+		head.Timestamp = time.UnixMilli(ms)
+	}
+
+	*h = head
+	return nil
+}
+
+// SignTreeHead returns the given tree head encoded as a signed note,
+// which is signed by every given signer.
+func SignTreeHead(head *TreeHead, signers ...*NoteSigner) ([]byte, error) {
+	text, err := head.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return signNote(text, signers...)
+}
+
+// CosignNote appends a signature by the given signer to an already signed note,
+// e.g. by a witness which has checked that the tree head it contains is consistent
+// with every tree head it previously cosigned.
+func CosignNote(note []byte, signer *NoteSigner) ([]byte, error) {
+	text, _, err := splitNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	cosigned := bytes.Clone(note)
+	return append(cosigned, signer.sign(text)...), nil
+}
+
+// OpenTreeHead verifies the signatures of the given signed note and returns the
+// tree head it contains. The note must carry valid signatures from at least
+// threshold of the given verifiers, with a minimum of one, e.g. the log itself and
+// a quorum of its witnesses. Signatures from signers which are not among the given
+// verifiers are ignored.
+func OpenTreeHead(note []byte, threshold int, verifiers ...*NoteVerifier) (*TreeHead, error) {
+	text, err := openNote(note, threshold, verifiers...)
+	if err != nil {
+		return nil, err
+	}
+
+	var head TreeHead
+	err = head.UnmarshalText(text)
+	if err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+func ExampleSignTreeHead() {
+	tree, err := ConstructBinaryTree(
+		sha256.New(),
+		strings.NewReader("a"),
+		strings.NewReader("b"),
+		strings.NewReader("c"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// in practice, the private key would be loaded from secure storage
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	signer, err := NewNoteSigner("example.com/log", key)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	note, err := SignTreeHead(&TreeHead{
+		Origin: "example.com/log",
+		Size:   uint64(tree.Size()),
+		Root:   tree.Hash(),
+	}, signer)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(string(note))
+
+	head, err := OpenTreeHead(note, 1, signer.Verifier())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(head.Size)
+
+	// Output: example.com/log
+	// 3
+	// cHUVLQOlzZIQSIe0doYneOwMh75cL6HAqQ+HxJ+tbv8=
+	//
+	// — example.com/log m+XTxj79wTdNOQyubS7eVsKqEL1JjJ2GpMWfP1gASwbWHvGGWPBilRyVnzhR47kNBJIr6OT55dclGc97ZWZ7mrYZzQ4=
+	// 3
+}
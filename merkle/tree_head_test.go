@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T, name string, seed byte) *NoteSigner {
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+
+	signer, err := NewNoteSigner(name, key)
+	require.Nil(t, err)
+	return signer
+}
+
+func testTreeHead(t *testing.T) *TreeHead {
+	tree, err := NewBinaryTree(sha256.New(), leafValues(10), WithScheme(RFC6962Scheme))
+	require.Nil(t, err)
+
+	return &TreeHead{
+		Origin:    "example.com/log",
+		Size:      uint64(tree.Size()),
+		Root:      tree.Hash(),
+		Timestamp: time.UnixMilli(1700000000123),
+	}
+}
+
+func TestTreeHead_MarshalText(t *testing.T) {
+	t.Run("will encode a checkpoint", func(t *testing.T) {
+		head := &TreeHead{
+			Origin: "example.com/log",
+			Size:   3,
+			Root:   []byte{1, 2, 3},
+		}
+
+		b, err := head.MarshalText()
+		require.Nil(t, err)
+		require.Equal(t, "example.com/log\n3\nAQID\n", string(b))
+
+		head.Timestamp = time.UnixMilli(1700000000123)
+		b, err = head.MarshalText()
+		require.Nil(t, err)
+		require.Equal(t, "example.com/log\n3\nAQID\ntimestamp 1700000000123\n", string(b))
+	})
+
+	t.Run("will round trip", func(t *testing.T) {
+		head := testTreeHead(t)
+
+		b, err := head.MarshalText()
+		require.Nil(t, err)
+
+		var decoded TreeHead
+		err = decoded.UnmarshalText(b)
+		require.Nil(t, err)
+		require.Equal(t, head.Origin, decoded.Origin)
+		require.Equal(t, head.Size, decoded.Size)
+		require.Equal(t, head.Root, decoded.Root)
+		require.True(t, head.Timestamp.Equal(decoded.Timestamp))
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the origin contains a newline", func(t *testing.T) {
+			head := testTreeHead(t)
+			head.Origin = "example.com\nlog"
+
+			_, err := head.MarshalText()
+			require.ErrorIs(t, err, ErrMalformedTreeHead)
+		})
+
+		t.Run("if the root is missing", func(t *testing.T) {
+			head := testTreeHead(t)
+			head.Root = nil
+
+			_, err := head.MarshalText()
+			require.ErrorIs(t, err, ErrMalformedTreeHead)
+		})
+	})
+}
+
+func TestTreeHead_UnmarshalText(t *testing.T) {
+	t.Run("will ignore unknown extension lines", func(t *testing.T) {
+		var head TreeHead
+		err := head.UnmarshalText([]byte("example.com/log\n3\nAQID\nsomething else\n"))
+		require.Nil(t, err)
+		require.Equal(t, uint64(3), head.Size)
+		require.True(t, head.Timestamp.IsZero())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		testCases := []struct {
+			Name string
+			Text string
+		}{
+			{Name: "if the text is empty", Text: ""},
+			{Name: "if the text does not end with a newline", Text: "example.com/log\n3\nAQID"},
+			{Name: "if the root is missing", Text: "example.com/log\n3\n"},
+			{Name: "if the size is not a number", Text: "example.com/log\nthree\nAQID\n"},
+			{Name: "if the root is not base64", Text: "example.com/log\n3\n!!!\n"},
+			{Name: "if the timestamp is not a number", Text: "example.com/log\n3\nAQID\ntimestamp now\n"},
+			{Name: "if there is an empty line", Text: "example.com/log\n3\nAQID\n\n"},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.Name, func(t *testing.T) {
+				var head TreeHead
+				err := head.UnmarshalText([]byte(testCase.Text))
+				require.ErrorIs(t, err, ErrMalformedTreeHead)
+			})
+		}
+	})
+}
+
+func TestOpenTreeHead(t *testing.T) {
+	log := newTestSigner(t, "example.com/log", 1)
+	witnesses := []*NoteSigner{
+		newTestSigner(t, "witness-1", 2),
+		newTestSigner(t, "witness-2", 3),
+		newTestSigner(t, "witness-3", 4),
+	}
+
+	head := testTreeHead(t)
+	note, err := SignTreeHead(head, log)
+	require.Nil(t, err)
+
+	cosigned := note
+	for _, witness := range witnesses[:2] {
+		cosigned, err = CosignNote(cosigned, witness)
+		require.Nil(t, err)
+	}
+
+	t.Run("will return the tree head if it is signed by the log", func(t *testing.T) {
+		opened, err := OpenTreeHead(note, 1, log.Verifier())
+		require.Nil(t, err)
+		require.Equal(t, head.Root, opened.Root)
+		require.Equal(t, head.Size, opened.Size)
+	})
+
+	t.Run("will return the tree head if it is cosigned by enough witnesses", func(t *testing.T) {
+		verifiers := []*NoteVerifier{
+			witnesses[0].Verifier(),
+			witnesses[1].Verifier(),
+			witnesses[2].Verifier(),
+		}
+
+		_, err := OpenTreeHead(cosigned, 2, verifiers...)
+		require.Nil(t, err)
+
+		_, err = OpenTreeHead(cosigned, 3, verifiers...)
+		require.ErrorIs(t, err, ErrInsufficientSignatures)
+	})
+
+	t.Run("will ignore signatures from unknown signers", func(t *testing.T) {
+		_, err := OpenTreeHead(cosigned, 1, log.Verifier())
+		require.Nil(t, err)
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		t.Run("if the note is not signed by any verifier", func(t *testing.T) {
+			_, err := OpenTreeHead(note, 0, witnesses[0].Verifier())
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+		})
+
+		t.Run("if the same verifier is given more than once", func(t *testing.T) {
+			duplicate, err := ParseNoteVerifier(log.Verifier().String())
+			require.Nil(t, err)
+
+			_, err = OpenTreeHead(note, 2, log.Verifier(), duplicate)
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+
+			_, err = OpenTreeHead(note, 2, log.Verifier(), log.Verifier())
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+		})
+
+		t.Run("if the same signature is repeated", func(t *testing.T) {
+			_, sig, ok := strings.Cut(string(note), "\n\n")
+			require.True(t, ok)
+
+			_, err := OpenTreeHead(append(slices.Clone(note), sig...), 2, log.Verifier(), witnesses[0].Verifier())
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+		})
+
+		t.Run("if the tree head has been tampered with", func(t *testing.T) {
+			tampered := []byte(strings.Replace(string(cosigned), "\n10\n", "\n11\n", 1))
+
+			_, err := OpenTreeHead(tampered, 1, log.Verifier())
+			require.ErrorIs(t, err, ErrInvalidSignature)
+		})
+
+		t.Run("if a signature is from a different key with the same name", func(t *testing.T) {
+			impostor := newTestSigner(t, "example.com/log", 5)
+			forged, err := SignTreeHead(head, impostor)
+			require.Nil(t, err)
+
+			_, err = OpenTreeHead(forged, 1, log.Verifier())
+			require.ErrorIs(t, err, ErrInsufficientSignatures)
+		})
+
+		t.Run("if the note is malformed", func(t *testing.T) {
+			testCases := []struct {
+				Name string
+				Note string
+			}{
+				{Name: "if there are no signatures", Note: "example.com/log\n3\nAQID\n"},
+				{Name: "if the signature line is malformed", Note: "example.com/log\n3\nAQID\n\nsignature\n"},
+				{Name: "if the signature is not base64", Note: "example.com/log\n3\nAQID\n\n— example.com/log !!!\n"},
+				{Name: "if the signature is too short", Note: "example.com/log\n3\nAQID\n\n— example.com/log AQID\n"},
+			}
+
+			for _, testCase := range testCases {
+				t.Run(testCase.Name, func(t *testing.T) {
+					_, err := OpenTreeHead([]byte(testCase.Note), 1, log.Verifier())
+					require.ErrorIs(t, err, ErrMalformedNote)
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"hash"
+	"io"
+)
+
+// Update returns a copy of this tree where the leaf at the given index has been
+// replaced by the given leaf. Only the nodes on the path from the leaf up to the
+// root are rehashed and copied, every other node is shared with this tree, which
+// remains unchanged and can therefore be kept as a snapshot. The hasher must be
+// the same as the one used to construct this tree.
+func (t *BinaryTree) Update(hasher hash.Hash, index int, leaf io.Reader) (*BinaryTree, error) {
+	if index < 0 || index >= t.size {
+		return nil, ErrLeafIndexOutOfRange
+	}
+	return t.update(hasher, index, leaf)
+}
+
+func (t *BinaryTree) update(hasher hash.Hash, index int, leaf io.Reader) (*BinaryTree, error) {
+	if t.IsLeaf() {
+		hash, err := t.scheme.hashLeaf(hasher, leaf)
+		if err != nil {
+			return nil, err
+		}
+
+		return &BinaryTree{
+			hash:   hash,
+			size:   1,
+			scheme: t.scheme,
+		}, nil
+	}
+
+	left, right := t.left, t.right
+
+	var err error
+	if index < left.size {
+		left, err = left.update(hasher, index, leaf)
+	} else {
+		right, err = right.update(hasher, index-left.size, leaf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := t.scheme.hashChildren(hasher, left.hash, right.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BinaryTree{
+		hash:   hash,
+		left:   left,
+		right:  right,
+		size:   t.size,
+		scheme: t.scheme,
+	}, nil
+}
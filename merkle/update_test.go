@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Z5Labs and Contributors
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package merkle
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryTree_Update(t *testing.T) {
+	t.Run("will have the same root as a reconstructed tree", func(t *testing.T) {
+		for n := 1; n <= 13; n++ {
+			tree, err := NewBinaryTree(sha256.New(), leafValues(n), WithScheme(DomainSeparatedScheme))
+			require.Nil(t, err)
+
+			for i := range n {
+				updated, err := tree.Update(sha256.New(), i, strings.NewReader("updated"))
+				require.Nil(t, err)
+
+				leafs := leafValues(n)
+				leafs[i] = strings.NewReader("updated")
+				expected, err := NewBinaryTree(sha256.New(), leafs, WithScheme(DomainSeparatedScheme))
+				require.Nil(t, err)
+				require.Equal(t, expected, updated)
+			}
+		}
+	})
+
+	t.Run("will keep the previous tree as a snapshot", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(8))
+		require.Nil(t, err)
+		root := tree.String()
+
+		updated, err := tree.Update(sha256.New(), 6, strings.NewReader("updated"))
+		require.Nil(t, err)
+		require.NotEqual(t, root, updated.String())
+		require.Equal(t, root, tree.String())
+
+		// only the path from the leaf to the root is copied
+		require.Same(t, tree.Left(), updated.Left())
+		require.Same(t, tree.Right().Left(), updated.Right().Left())
+		require.NotSame(t, tree.Right().Right(), updated.Right().Right())
+	})
+
+	t.Run("will return an error", func(t *testing.T) {
+		tree, err := NewBinaryTree(sha256.New(), leafValues(4))
+		require.Nil(t, err)
+
+		t.Run("if the index is out of range", func(t *testing.T) {
+			_, err := tree.Update(sha256.New(), 4, strings.NewReader("updated"))
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+
+			_, err = tree.Update(sha256.New(), -1, strings.NewReader("updated"))
+			require.ErrorIs(t, err, ErrLeafIndexOutOfRange)
+		})
+
+		t.Run("if the leaf fails to be read", func(t *testing.T) {
+			root := tree.String()
+
+			updated, err := tree.Update(sha256.New(), 1, readFunc(func(b []byte) (int, error) {
+				return 0, errReadFailed
+			}))
+			require.ErrorIs(t, err, errReadFailed)
+			require.Nil(t, updated)
+			require.Equal(t, root, tree.String())
+		})
+	})
+}